@@ -0,0 +1,69 @@
+package slacker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/slack-go/slack/socketmode"
+)
+
+const (
+	// ackTimeout leaves some margin before Slack gives up on the
+	// acknowledgement after 3 seconds
+	ackTimeout = 2500 * time.Millisecond
+)
+
+// acknowledger acknowledges a socket mode request exactly once, either with a
+// payload or empty once its handler returns or the acknowledgement deadline
+// approaches
+type acknowledger struct {
+	request *socketmode.Request
+	client  *socketmode.Client
+
+	mutex sync.Mutex
+	acked bool
+	timer *time.Timer
+}
+
+func newAcknowledger(client *socketmode.Client, request *socketmode.Request) *acknowledger {
+	ack := &acknowledger{request: request, client: client}
+	ack.timer = time.AfterFunc(ackTimeout, func() { ack.ack(nil) })
+	return ack
+}
+
+// ack acknowledges the request with the payload, reporting whether this call
+// sent the acknowledgement
+func (a *acknowledger) ack(payload interface{}) bool {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.acked {
+		return false
+	}
+	a.acked = true
+	a.timer.Stop()
+
+	if payload == nil {
+		a.client.Ack(*a.request)
+	} else {
+		a.client.Ack(*a.request, payload)
+	}
+	return true
+}
+
+type acknowledgerContextKey struct{}
+
+func withAcknowledger(ctx context.Context, ack *acknowledger) context.Context {
+	return context.WithValue(ctx, acknowledgerContextKey{}, ack)
+}
+
+// acknowledgerFromContext returns the acknowledger of the interaction being
+// handled, nil if the event is not an interaction
+func acknowledgerFromContext(ctx context.Context) *acknowledger {
+	if ctx == nil {
+		return nil
+	}
+	ack, _ := ctx.Value(acknowledgerContextKey{}).(*acknowledger)
+	return ack
+}
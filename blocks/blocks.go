@@ -0,0 +1,249 @@
+// Package blocks builds Block Kit layouts for Slack messages, validating them
+// against Slack's limits before they are sent.
+package blocks
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/slack-go/slack"
+)
+
+const (
+	empty = ""
+
+	maxBlocks            = 50
+	maxSectionTextLength = 3000
+	maxFields            = 10
+	maxFieldLength       = 2000
+	maxHeaderLength      = 150
+	maxContextElements   = 10
+	maxActionElements    = 25
+	maxButtonTextLength  = 75
+	maxButtonValueLength = 2000
+	maxPlaceholderLength = 150
+	maxSelectOptions     = 100
+	maxOptionTextLength  = 75
+	maxOptionValueLength = 75
+	maxAltTextLength     = 2000
+	maxImageTitleLength  = 2000
+	maxURLLength         = 3000
+	maxIDLength          = 255
+)
+
+// Builder builds a list of blocks, eg.
+//
+//	blocks.New().
+//		Header("Deployment").
+//		Section(blocks.Markdown("Deploy *api* to production?")).
+//		Actions("deploy", blocks.Button("approve", "Approve", "api").Primary()).
+//		Build()
+type Builder struct {
+	blocks    []slack.Block
+	errors    []string
+	blockIDs  map[string]bool
+	actionIDs map[string]bool
+}
+
+// New creates an empty builder
+func New() *Builder {
+	return &Builder{blockIDs: make(map[string]bool), actionIDs: make(map[string]bool)}
+}
+
+// SectionOption an option for section values
+type SectionOption func(*sectionDefaults)
+
+type sectionDefaults struct {
+	blockID   string
+	fields    []*Text
+	accessory Element
+}
+
+// WithFields adds fields, shown in two columns, to the section
+func WithFields(fields ...*Text) SectionOption {
+	return func(defaults *sectionDefaults) {
+		defaults.fields = append(defaults.fields, fields...)
+	}
+}
+
+// WithAccessory shows the element next to the text of the section
+func WithAccessory(element Element) SectionOption {
+	return func(defaults *sectionDefaults) {
+		defaults.accessory = element
+	}
+}
+
+// WithBlockID sets the block ID of the section
+func WithBlockID(blockID string) SectionOption {
+	return func(defaults *sectionDefaults) {
+		defaults.blockID = blockID
+	}
+}
+
+// Section adds a section of text, with optional fields and accessory. The
+// text can be nil when fields are given.
+func (b *Builder) Section(text *Text, options ...SectionOption) *Builder {
+	defaults := &sectionDefaults{}
+	for _, option := range options {
+		option(defaults)
+	}
+
+	if text == nil && len(defaults.fields) == 0 {
+		b.fail("section has neither text nor fields")
+	}
+	b.checkLength("section text", text.length(), maxSectionTextLength)
+	if len(defaults.fields) > maxFields {
+		b.fail(fmt.Sprintf("section has %d fields, at most %d are allowed", len(defaults.fields), maxFields))
+	}
+
+	var fields []*slack.TextBlockObject
+	for _, field := range defaults.fields {
+		b.checkLength("section field", field.length(), maxFieldLength)
+		fields = append(fields, field.object())
+	}
+
+	var accessory *slack.Accessory
+	if defaults.accessory != nil {
+		b.addElement(defaults.accessory)
+		accessory = slack.NewAccessory(defaults.accessory.element())
+	}
+
+	b.addBlockID(defaults.blockID)
+	return b.add(slack.NewSectionBlock(text.object(), fields, accessory, slack.SectionBlockOptionBlockID(defaults.blockID)))
+}
+
+// Fields adds a section made of fields only
+func (b *Builder) Fields(fields ...*Text) *Builder {
+	return b.Section(nil, WithFields(fields...))
+}
+
+// Header adds a header in large, bold plain text
+func (b *Builder) Header(text string) *Builder {
+	b.checkLength("header text", len([]rune(text)), maxHeaderLength)
+	return b.add(slack.NewHeaderBlock(Plain(text).object()))
+}
+
+// Context adds a context block of small text
+func (b *Builder) Context(texts ...*Text) *Builder {
+	if len(texts) == 0 {
+		b.fail("context has no elements")
+	}
+	if len(texts) > maxContextElements {
+		b.fail(fmt.Sprintf("context has %d elements, at most %d are allowed", len(texts), maxContextElements))
+	}
+
+	var elements []slack.MixedElement
+	for _, text := range texts {
+		elements = append(elements, text.object())
+	}
+	return b.add(slack.NewContextBlock(empty, elements...))
+}
+
+// Divider adds a horizontal line
+func (b *Builder) Divider() *Builder {
+	return b.add(slack.NewDividerBlock())
+}
+
+// Image adds an image, the title is optional
+func (b *Builder) Image(url string, altText string, title string) *Builder {
+	b.checkLength("image url", len(url), maxURLLength)
+	b.checkLength("image alt text", len([]rune(altText)), maxAltTextLength)
+	b.checkLength("image title", len([]rune(title)), maxImageTitleLength)
+	if len(url) == 0 || len(altText) == 0 {
+		b.fail("image requires a url and alt text")
+	}
+
+	var titleObject *slack.TextBlockObject
+	if len(title) > 0 {
+		titleObject = Plain(title).object()
+	}
+	return b.add(slack.NewImageBlock(url, altText, empty, titleObject))
+}
+
+// Actions adds a block of interactive elements, such as buttons and selects
+func (b *Builder) Actions(blockID string, elements ...Element) *Builder {
+	if len(elements) == 0 {
+		b.fail(fmt.Sprintf("actions %q have no elements", blockID))
+	}
+	if len(elements) > maxActionElements {
+		b.fail(fmt.Sprintf("actions %q have %d elements, at most %d are allowed", blockID, len(elements), maxActionElements))
+	}
+
+	var blockElements []slack.BlockElement
+	for _, element := range elements {
+		b.addElement(element)
+		blockElements = append(blockElements, element.element())
+	}
+
+	b.addBlockID(blockID)
+	return b.add(slack.NewActionBlock(blockID, blockElements...))
+}
+
+// Build returns the blocks, or an error describing every limit they exceed
+func (b *Builder) Build() ([]slack.Block, error) {
+	errs := b.errors
+	if len(b.blocks) > maxBlocks {
+		errs = append(errs, fmt.Sprintf("%d blocks, at most %d are allowed", len(b.blocks), maxBlocks))
+	}
+	if len(errs) > 0 {
+		return nil, errors.New("invalid blocks: " + strings.Join(errs, "; "))
+	}
+	return b.blocks, nil
+}
+
+// MustBuild returns the blocks and panics if they are invalid, for layouts that
+// are fixed at compile time
+func (b *Builder) MustBuild() []slack.Block {
+	blocks, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+	return blocks
+}
+
+func (b *Builder) add(block slack.Block) *Builder {
+	b.blocks = append(b.blocks, block)
+	return b
+}
+
+func (b *Builder) fail(err string) {
+	b.errors = append(b.errors, err)
+}
+
+func (b *Builder) checkLength(name string, length int, limit int) {
+	b.errors = appendIfLonger(b.errors, name, length, limit)
+}
+
+func (b *Builder) addBlockID(blockID string) {
+	if len(blockID) == 0 {
+		return
+	}
+	b.checkLength("block id", len(blockID), maxIDLength)
+	if b.blockIDs[blockID] {
+		b.fail(fmt.Sprintf("block id %q is used more than once", blockID))
+	}
+	b.blockIDs[blockID] = true
+}
+
+func (b *Builder) addElement(element Element) {
+	b.errors = append(b.errors, element.validate()...)
+
+	actionID := element.ActionID()
+	if len(actionID) == 0 {
+		b.fail("element has no action id")
+		return
+	}
+	b.checkLength("action id", len(actionID), maxIDLength)
+	if b.actionIDs[actionID] {
+		b.fail(fmt.Sprintf("action id %q is used more than once", actionID))
+	}
+	b.actionIDs[actionID] = true
+}
+
+func appendIfLonger(errors []string, name string, length int, limit int) []string {
+	if length <= limit {
+		return errors
+	}
+	return append(errors, fmt.Sprintf("%s has %d characters, at most %d are allowed", name, length, limit))
+}
@@ -0,0 +1,143 @@
+package blocks
+
+import (
+	"fmt"
+
+	"github.com/slack-go/slack"
+)
+
+// Element is an interactive element of an actions block or section accessory
+type Element interface {
+	ActionID() string
+	element() slack.BlockElement
+	validate() []string
+}
+
+// ButtonElement is a button, created with Button
+type ButtonElement struct {
+	actionID string
+	text     *Text
+	value    string
+	url      string
+	style    slack.Style
+}
+
+// Button creates a button sending the value with its action
+func Button(actionID string, text string, value string) *ButtonElement {
+	return &ButtonElement{actionID: actionID, text: Plain(text), value: value}
+}
+
+// Primary gives the button the primary style
+func (b *ButtonElement) Primary() *ButtonElement {
+	b.style = slack.StylePrimary
+	return b
+}
+
+// Danger gives the button the danger style
+func (b *ButtonElement) Danger() *ButtonElement {
+	b.style = slack.StyleDanger
+	return b
+}
+
+// URL makes the button open the link in the user's browser
+func (b *ButtonElement) URL(url string) *ButtonElement {
+	b.url = url
+	return b
+}
+
+// ActionID returns the action ID of the button
+func (b *ButtonElement) ActionID() string {
+	return b.actionID
+}
+
+func (b *ButtonElement) element() slack.BlockElement {
+	button := slack.NewButtonBlockElement(b.actionID, b.value, b.text.plain())
+	button.URL = b.url
+	if len(b.style) > 0 {
+		button = button.WithStyle(b.style)
+	}
+	return button
+}
+
+func (b *ButtonElement) validate() []string {
+	var errors []string
+	errors = appendIfLonger(errors, "button text", b.text.length(), maxButtonTextLength)
+	errors = appendIfLonger(errors, "button value", len(b.value), maxButtonValueLength)
+	errors = appendIfLonger(errors, "button url", len(b.url), maxURLLength)
+	return errors
+}
+
+// SelectElement is a menu of static options, created with Select
+type SelectElement struct {
+	actionID    string
+	placeholder *Text
+	options     []*OptionObject
+	initial     string
+}
+
+// OptionObject is an option of a select menu, created with Option
+type OptionObject struct {
+	text  *Text
+	value string
+}
+
+// Option creates an option of a select menu
+func Option(text string, value string) *OptionObject {
+	return &OptionObject{text: Plain(text), value: value}
+}
+
+// Select creates a menu of static options
+func Select(actionID string, placeholder string, options ...*OptionObject) *SelectElement {
+	return &SelectElement{actionID: actionID, placeholder: Plain(placeholder), options: options}
+}
+
+// Initial selects the option with the value by default
+func (s *SelectElement) Initial(value string) *SelectElement {
+	s.initial = value
+	return s
+}
+
+// ActionID returns the action ID of the menu
+func (s *SelectElement) ActionID() string {
+	return s.actionID
+}
+
+func (s *SelectElement) element() slack.BlockElement {
+	var options []*slack.OptionBlockObject
+	var initial *slack.OptionBlockObject
+	for _, option := range s.options {
+		object := slack.NewOptionBlockObject(option.value, option.text.plain(), nil)
+		if option.value == s.initial {
+			initial = object
+		}
+		options = append(options, object)
+	}
+
+	menu := slack.NewOptionsSelectBlockElement(slack.OptTypeStatic, s.placeholder.plain(), s.actionID, options...)
+	menu.InitialOption = initial
+	return menu
+}
+
+func (s *SelectElement) validate() []string {
+	var errors []string
+	errors = appendIfLonger(errors, "select placeholder", s.placeholder.length(), maxPlaceholderLength)
+	if len(s.options) == 0 {
+		errors = append(errors, fmt.Sprintf("select %q has no options", s.actionID))
+	}
+	if len(s.options) > maxSelectOptions {
+		errors = append(errors, fmt.Sprintf("select %q has %d options, at most %d are allowed", s.actionID, len(s.options), maxSelectOptions))
+	}
+
+	found := len(s.initial) == 0
+	for _, option := range s.options {
+		errors = appendIfLonger(errors, "option text", option.text.length(), maxOptionTextLength)
+		errors = appendIfLonger(errors, "option value", len(option.value), maxOptionValueLength)
+		if option.value == s.initial {
+			found = true
+		}
+	}
+	if !found {
+		errors = append(errors, fmt.Sprintf("select %q has no option with the initial value %q", s.actionID, s.initial))
+	}
+	return errors
+}
@@ -0,0 +1,45 @@
+package blocks
+
+import "github.com/slack-go/slack"
+
+// Text is a plain text or markdown text object
+type Text struct {
+	kind string
+	text string
+}
+
+// Markdown creates a text object formatted with Slack's markdown
+func Markdown(text string) *Text {
+	return &Text{kind: slack.MarkdownType, text: text}
+}
+
+// Plain creates a plain text object, emoji codes are rendered
+func Plain(text string) *Text {
+	return &Text{kind: slack.PlainTextType, text: text}
+}
+
+func (t *Text) object() *slack.TextBlockObject {
+	if t == nil {
+		return nil
+	}
+	if t.kind == slack.PlainTextType {
+		return slack.NewTextBlockObject(t.kind, t.text, true, false)
+	}
+	return slack.NewTextBlockObject(t.kind, t.text, false, false)
+}
+
+// plain converts markdown to plain text for the places Slack only accepts
+// plain text
+func (t *Text) plain() *slack.TextBlockObject {
+	if t == nil {
+		return nil
+	}
+	return slack.NewTextBlockObject(slack.PlainTextType, t.text, true, false)
+}
+
+func (t *Text) length() int {
+	if t == nil {
+		return 0
+	}
+	return len([]rune(t.text))
+}
@@ -0,0 +1,266 @@
+package slacker
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/slackevents"
+)
+
+const (
+	channelInfoTTL = 5 * time.Minute
+)
+
+// ConversationType is a kind of Slack conversation a command can be limited to
+type ConversationType int
+
+const (
+	// ConversationTypeDirect is a direct message between the bot and a user
+	ConversationTypeDirect ConversationType = iota + 1
+
+	// ConversationTypeGroupDirect is a direct message with several users
+	ConversationTypeGroupDirect
+
+	// ConversationTypePublic is a public channel
+	ConversationTypePublic
+
+	// ConversationTypePrivate is a private channel
+	ConversationTypePrivate
+
+	// ConversationTypeShared is a channel shared with other workspaces or
+	// organizations through Slack Connect
+	ConversationTypeShared
+)
+
+// ThreadMode limits a command to threaded or top level messages
+type ThreadMode int
+
+const (
+	// ThreadModeAny executes the command wherever it was sent
+	ThreadModeAny ThreadMode = iota
+
+	// ThreadModeOnly executes the command only when sent inside a thread
+	ThreadModeOnly
+
+	// ThreadModeTopLevelOnly executes the command only when sent outside of a
+	// thread
+	ThreadModeTopLevelOnly
+)
+
+// ChannelPolicy restricts where a command can be executed. Empty fields impose
+// no restriction, and exclusions take precedence over inclusions.
+type ChannelPolicy struct {
+	// IncludeChannelIDs allows the command only in these channel IDs
+	IncludeChannelIDs []string
+
+	// ExcludeChannelIDs forbids the command in these channel IDs
+	ExcludeChannelIDs []string
+
+	// IncludeChannelNames allows the command only in channels whose name
+	// matches one of these regular expressions. Patterns must match the whole
+	// name, eg. `ops-.*`
+	IncludeChannelNames []string
+
+	// ExcludeChannelNames forbids the command in channels whose name matches
+	// one of these regular expressions
+	ExcludeChannelNames []string
+
+	// ConversationTypes allows the command only in conversations of one of
+	// these types
+	ConversationTypes []ConversationType
+
+	// ThreadMode limits the command to threaded or top level messages
+	ThreadMode ThreadMode
+
+	compileOnce  sync.Once
+	includeNames []*regexp.Regexp
+	excludeNames []*regexp.Regexp
+	err          error
+}
+
+// compile compiles the channel name patterns once, when the command is
+// registered
+func (p *ChannelPolicy) compile() {
+	p.compileOnce.Do(func() {
+		p.includeNames, p.err = compileChannelPatterns(p.IncludeChannelNames)
+		if p.err != nil {
+			return
+		}
+		p.excludeNames, p.err = compileChannelPatterns(p.ExcludeChannelNames)
+	})
+}
+
+// validate returns the first invalid channel name pattern of the policy
+func (p *ChannelPolicy) validate() error {
+	p.compile()
+	return p.err
+}
+
+// allows determines whether the event satisfies the policy, looking up the
+// conversation through the API only when the policy needs it
+func (p *ChannelPolicy) allows(s *Slacker, ev *MessageEvent) (bool, error) {
+	p.compile()
+	if p.err != nil {
+		return false, p.err
+	}
+
+	switch p.ThreadMode {
+	case ThreadModeOnly:
+		if !ev.IsThread() {
+			return false, nil
+		}
+	case ThreadModeTopLevelOnly:
+		if ev.IsThread() {
+			return false, nil
+		}
+	}
+
+	if contains(p.ExcludeChannelIDs, ev.Channel) {
+		return false, nil
+	}
+	if len(p.IncludeChannelIDs) > 0 && !contains(p.IncludeChannelIDs, ev.Channel) {
+		return false, nil
+	}
+
+	if len(p.IncludeChannelNames) > 0 || len(p.ExcludeChannelNames) > 0 {
+		name := ev.ChannelName
+		if len(name) == 0 || name == ev.Channel {
+			info, err := s.channelInfo(ev.Channel)
+			if err != nil {
+				return false, err
+			}
+			name = info.Name
+		}
+
+		if matchesChannelPattern(p.excludeNames, name) {
+			return false, nil
+		}
+		if len(p.includeNames) > 0 && !matchesChannelPattern(p.includeNames, name) {
+			return false, nil
+		}
+	}
+
+	if len(p.ConversationTypes) > 0 {
+		types, err := s.conversationTypes(ev)
+		if err != nil {
+			return false, err
+		}
+		for _, allowed := range p.ConversationTypes {
+			if types[allowed] {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// conversationTypes lists the types the event's conversation belongs to
+func (s *Slacker) conversationTypes(ev *MessageEvent) (map[ConversationType]bool, error) {
+	if strings.HasPrefix(ev.Channel, directChannelMarker) {
+		return map[ConversationType]bool{ConversationTypeDirect: true}, nil
+	}
+
+	if msg, ok := ev.Data.(*slackevents.MessageEvent); ok && msg.ChannelType == "mpim" {
+		return map[ConversationType]bool{ConversationTypeGroupDirect: true}, nil
+	}
+
+	info, err := s.channelInfo(ev.Channel)
+	if err != nil {
+		return nil, err
+	}
+
+	types := make(map[ConversationType]bool)
+	switch {
+	case info.IsIM:
+		types[ConversationTypeDirect] = true
+	case info.IsMpIM:
+		types[ConversationTypeGroupDirect] = true
+	case info.IsPrivate:
+		types[ConversationTypePrivate] = true
+	default:
+		types[ConversationTypePublic] = true
+	}
+	if info.IsExtShared || info.IsShared || info.IsOrgShared || info.IsPendingExtShared {
+		types[ConversationTypeShared] = true
+	}
+	return types, nil
+}
+
+type cachedChannelInfo struct {
+	channel *slack.Channel
+	expires time.Time
+}
+
+type channelInfoCache struct {
+	mutex    sync.Mutex
+	channels map[string]cachedChannelInfo
+}
+
+// channelInfo retrieves conversation information, caching it for a short
+// while since policies are evaluated for every command of every message
+func (s *Slacker) channelInfo(channelID string) (*slack.Channel, error) {
+	cache := &s.channelCache
+	cache.mutex.Lock()
+	cached, ok := cache.channels[channelID]
+	cache.mutex.Unlock()
+	if ok && time.Now().Before(cached.expires) {
+		return cached.channel, nil
+	}
+
+	channel, err := s.client.GetConversationInfo(channelID, false)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get channel info for %s: %v", channelID, err)
+	}
+
+	cache.mutex.Lock()
+	if cache.channels == nil {
+		cache.channels = make(map[string]cachedChannelInfo)
+	}
+	cache.channels[channelID] = cachedChannelInfo{channel: channel, expires: time.Now().Add(channelInfoTTL)}
+	cache.mutex.Unlock()
+	return channel, nil
+}
+
+func compileChannelPattern(pattern string) (*regexp.Regexp, error) {
+	expression, err := regexp.Compile("^(?:" + pattern + ")$")
+	if err != nil {
+		return nil, fmt.Errorf("invalid channel name pattern %q: %v", pattern, err)
+	}
+	return expression, nil
+}
+
+func compileChannelPatterns(patterns []string) ([]*regexp.Regexp, error) {
+	expressions := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		expression, err := compileChannelPattern(pattern)
+		if err != nil {
+			return nil, err
+		}
+		expressions = append(expressions, expression)
+	}
+	return expressions, nil
+}
+
+func matchesChannelPattern(expressions []*regexp.Regexp, name string) bool {
+	for _, expression := range expressions {
+		if expression.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(list []string, element string) bool {
+	for _, value := range list {
+		if value == element {
+			return true
+		}
+	}
+	return false
+}
@@ -5,8 +5,6 @@ import (
 	"strings"
 
 	allot "github.com/sdslabs/allot/pkg"
-	"github.com/slack-go/slack"
-	"github.com/slack-go/slack/socketmode"
 )
 
 // CommandDefinition structure contains definition of the bot command
@@ -16,7 +14,19 @@ type CommandDefinition struct {
 	BlockID           string
 	AuthorizationFunc func(botCtx BotContext, request Request) bool
 	Handler           func(botCtx BotContext, request Request, response ResponseWriter)
-	Interactive       func(*Slacker, *socketmode.Event, *slack.InteractionCallback, *socketmode.Request)
+	Interactive       func(botCtx BotContext, request Request, response ResponseWriter)
+
+	// ChannelPolicy restricts the conversations and threads the command can be
+	// executed in, in addition to the include channels filter.
+	ChannelPolicy *ChannelPolicy
+
+	// LifecycleReactions mark the triggering message while the command runs,
+	// overriding the bot's reactions set with WithLifecycleReactions.
+	LifecycleReactions *LifecycleReactions
+
+	// ReplyPlacement decides whether replies are threaded, overriding the
+	// bot's placement set with WithReplyPlacement.
+	ReplyPlacement ReplyPlacement
 
 	// HideHelp will cause this command to not be shown when a user requests
 	// help.
@@ -26,6 +36,9 @@ type CommandDefinition struct {
 // NewBotCommand creates a new bot command object
 func NewBotCommand(usage string, definition *CommandDefinition, isParameterizedCommand bool, includeChannelIds []string) BotCommand {
 	command := allot.New(usage)
+	if definition != nil && definition.ChannelPolicy != nil {
+		definition.ChannelPolicy.compile()
+	}
 	return &botCommand{
 		usage:                  usage,
 		definition:             definition,
@@ -48,7 +61,7 @@ type BotCommand interface {
 	Tokenize() []*allot.Token
 	Parameters() []allot.Parameter
 	Execute(botCtx BotContext, request Request, response ResponseWriter)
-	Interactive(*Slacker, *socketmode.Event, *slack.InteractionCallback, *socketmode.Request)
+	Interactive(botCtx BotContext, request Request, response ResponseWriter)
 }
 
 // botCommand structure contains the bot's command, description and handler
@@ -75,6 +88,7 @@ func (c *botCommand) IsParameterizedCommand() bool {
 	return c.isParameterizedCommand
 }
 
+// ContainsChannel checks whether the include channels filter allows the channel
 func (c *botCommand) ContainsChannel(channelId string) bool {
 	if reflect.DeepEqual(c.includeChannelIds, defaultIncludeChannelIds) {
 		return true
@@ -120,9 +134,9 @@ func (c *botCommand) Execute(botCtx BotContext, request Request, response Respon
 }
 
 // Interactive executes the interactive logic
-func (c *botCommand) Interactive(slacker *Slacker, evt *socketmode.Event, callback *slack.InteractionCallback, req *socketmode.Request) {
+func (c *botCommand) Interactive(botCtx BotContext, request Request, response ResponseWriter) {
 	if c.definition == nil || c.definition.Interactive == nil {
 		return
 	}
-	c.definition.Interactive(slacker, evt, callback, req)
+	c.definition.Interactive(botCtx, request, response)
 }
@@ -7,6 +7,24 @@ import (
 	"github.com/slack-go/slack/socketmode"
 )
 
+type slackerContextKey struct{}
+
+// withSlacker stores the bot in the context handed to handlers, so that
+// response writers and contexts can reach bot wide state
+func withSlacker(ctx context.Context, s *Slacker) context.Context {
+	return context.WithValue(ctx, slackerContextKey{}, s)
+}
+
+// slackerFromContext returns the bot handling the event, nil if the context
+// was not created by Slacker
+func slackerFromContext(ctx context.Context) *Slacker {
+	if ctx == nil {
+		return nil
+	}
+	s, _ := ctx.Value(slackerContextKey{}).(*Slacker)
+	return s
+}
+
 // A BotContext interface is used to respond to an event
 type BotContext interface {
 	Context() context.Context
@@ -0,0 +1,234 @@
+package slacker
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrConversationTimeout is returned by Ask when the user does not reply in time
+	ErrConversationTimeout = errors.New("timed out waiting for a reply")
+
+	// ErrConversationReplaced is returned by Ask when another question is asked
+	// in the same conversation before the user replied
+	ErrConversationReplaced = errors.New("question was replaced by another one")
+
+	// ErrConversationCanceled is returned by Ask when the conversation is
+	// canceled before the user replied
+	ErrConversationCanceled = errors.New("conversation was canceled")
+)
+
+// ConversationKey identifies the user, channel and thread a conversation takes
+// place in. Thread is empty for direct messages and for top level messages of
+// a channel.
+type ConversationKey struct {
+	User    string
+	Channel string
+	Thread  string
+}
+
+// NewConversationKey returns the key of the conversation the event belongs to.
+// Direct messages ignore threads, while in channels a conversation either
+// continues in the thread of the event or at the top level of the channel.
+func NewConversationKey(ev *MessageEvent) ConversationKey {
+	key := ConversationKey{User: ev.User, Channel: ev.Channel}
+	if !strings.HasPrefix(ev.Channel, directChannelMarker) && ev.IsThread() {
+		key.Thread = ev.ThreadTimeStamp
+	}
+	return key
+}
+
+// ConversationStep handles the next message of a conversation
+type ConversationStep struct {
+	// Handler receives the reply once it passed validation. It can register
+	// another step to continue or branch the conversation.
+	Handler func(botCtx BotContext, request Request, response ResponseWriter)
+
+	// Validate optionally checks the reply. When it returns an error, the
+	// error is reported to the user and the step waits for another reply.
+	Validate func(text string) error
+
+	// Timeout is how long to wait for the reply, defaults to 5 minutes
+	Timeout time.Duration
+
+	// OnTimeout is called if the user did not reply in time
+	OnTimeout func(key ConversationKey)
+}
+
+const (
+	defaultConversationTimeout = 5 * time.Minute
+)
+
+type conversationResult struct {
+	reply *MessageEvent
+	err   error
+}
+
+type conversationWaiter struct {
+	step    *ConversationStep
+	results chan conversationResult
+	timer   *time.Timer
+}
+
+// finish hands the outcome to a waiting question, steps have nobody to notify
+func (w *conversationWaiter) finish(reply *MessageEvent, err error) {
+	if w.results == nil {
+		return
+	}
+	select {
+	case w.results <- conversationResult{reply: reply, err: err}:
+	default:
+	}
+}
+
+type conversationManager struct {
+	mutex   sync.Mutex
+	waiters map[ConversationKey]*conversationWaiter
+}
+
+// wait registers a waiter, replacing any earlier one for the same key
+func (m *conversationManager) wait(key ConversationKey, waiter *conversationWaiter, timeout time.Duration, onTimeout func()) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.waiters == nil {
+		m.waiters = make(map[ConversationKey]*conversationWaiter)
+	}
+	if previous, ok := m.waiters[key]; ok {
+		previous.timer.Stop()
+		previous.finish(nil, ErrConversationReplaced)
+	}
+
+	waiter.timer = time.AfterFunc(timeout, func() {
+		if m.remove(key, waiter) && onTimeout != nil {
+			onTimeout()
+		}
+	})
+	m.waiters[key] = waiter
+}
+
+// remove unregisters the waiter if it is still the current one for the key
+func (m *conversationManager) remove(key ConversationKey, waiter *conversationWaiter) bool {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.waiters[key] != waiter {
+		return false
+	}
+	waiter.timer.Stop()
+	delete(m.waiters, key)
+	return true
+}
+
+// take unregisters and returns the waiter expecting the message, if any
+func (m *conversationManager) take(ev *MessageEvent) (ConversationKey, *conversationWaiter) {
+	key := NewConversationKey(ev)
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	waiter, ok := m.waiters[key]
+	if !ok {
+		return key, nil
+	}
+	waiter.timer.Stop()
+	delete(m.waiters, key)
+	return key, waiter
+}
+
+// Await routes the next message the user sends in the conversation to the
+// step instead of the registered commands
+func (s *Slacker) Await(key ConversationKey, step *ConversationStep) {
+	timeout := step.Timeout
+	if timeout <= 0 {
+		timeout = defaultConversationTimeout
+	}
+
+	var onTimeout func()
+	if step.OnTimeout != nil {
+		onTimeout = func() { step.OnTimeout(key) }
+	}
+	s.conversations.wait(key, &conversationWaiter{step: step}, timeout, onTimeout)
+}
+
+// CancelConversation stops waiting for a reply in the conversation
+func (s *Slacker) CancelConversation(key ConversationKey) {
+	s.conversations.mutex.Lock()
+	waiter, ok := s.conversations.waiters[key]
+	s.conversations.mutex.Unlock()
+	if !ok {
+		return
+	}
+
+	if s.conversations.remove(key, waiter) {
+		waiter.finish(nil, ErrConversationCanceled)
+	}
+}
+
+// ask sends the question through the response in the conversation of the
+// event and blocks until the user replies, the timeout expires or the context
+// is done
+func (s *Slacker) ask(r *response, question string, timeout time.Duration) (*MessageEvent, error) {
+	botCtx := r.botCtx
+	ev := botCtx.Event()
+	if ev == nil {
+		return nil, fmt.Errorf("unable to get message event details")
+	}
+
+	key := NewConversationKey(ev)
+	if len(key.User) == 0 {
+		return nil, fmt.Errorf("unable to ask without a user to reply")
+	}
+
+	if timeout <= 0 {
+		timeout = defaultConversationTimeout
+	}
+
+	waiter := &conversationWaiter{results: make(chan conversationResult, 1)}
+	s.conversations.wait(key, waiter, timeout, func() { waiter.finish(nil, ErrConversationTimeout) })
+
+	if _, err := r.send(ev, &message{text: question, thread: key.Thread}); err != nil {
+		s.conversations.remove(key, waiter)
+		return nil, err
+	}
+
+	select {
+	case result := <-waiter.results:
+		return result.reply, result.err
+	case <-botCtx.Context().Done():
+		s.conversations.remove(key, waiter)
+		return nil, botCtx.Context().Err()
+	}
+}
+
+// continueConversation hands the message to a waiting question or step and
+// reports whether it was consumed
+func (s *Slacker) continueConversation(botCtx BotContext, response ResponseWriter) bool {
+	ev := botCtx.Event()
+	key, waiter := s.conversations.take(ev)
+	if waiter == nil {
+		return false
+	}
+
+	if waiter.step == nil {
+		waiter.finish(ev, nil)
+		return true
+	}
+
+	step := waiter.step
+	if step.Validate != nil {
+		if err := step.Validate(s.cleanEventInput(ev.Text)); err != nil {
+			response.ReportError(err)
+			s.Await(key, step)
+			return true
+		}
+	}
+
+	if step.Handler != nil {
+		step.Handler(botCtx, s.requestConstructor(botCtx, nil, nil), response)
+	}
+	return true
+}
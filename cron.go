@@ -0,0 +1,267 @@
+package slacker
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	cronTimeZonePrefix = "CRON_TZ="
+	timeZonePrefix     = "TZ="
+	everyDescriptor    = "@every "
+	cronSearchYears    = 5
+)
+
+// cronSchedule returns the next activation time strictly after the given time
+type cronSchedule interface {
+	next(t time.Time) time.Time
+}
+
+// everySchedule activates at a fixed interval
+type everySchedule struct {
+	interval time.Duration
+}
+
+func (e everySchedule) next(t time.Time) time.Time {
+	return t.Add(e.interval)
+}
+
+// fieldSchedule activates whenever all fields match, each field is a bit set
+// of the allowed values
+type fieldSchedule struct {
+	minute, hour, dom, month, dow uint64
+	location                      *time.Location
+}
+
+type cronField struct {
+	min, max int
+	names    map[string]int
+
+	// wildcardMax is the highest value matched by "*" when it differs from max
+	wildcardMax int
+}
+
+var (
+	minuteField = cronField{min: 0, max: 59}
+	hourField   = cronField{min: 0, max: 23}
+	domField    = cronField{min: 1, max: 31}
+	monthField  = cronField{min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	dowField = cronField{min: 0, max: 7, wildcardMax: 6, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+
+	cronDescriptors = map[string]string{
+		"@yearly":   "0 0 1 1 *",
+		"@annually": "0 0 1 1 *",
+		"@monthly":  "0 0 1 * *",
+		"@weekly":   "0 0 * * 0",
+		"@daily":    "0 0 * * *",
+		"@midnight": "0 0 * * *",
+		"@hourly":   "0 * * * *",
+	}
+)
+
+// parseCronSpec parses a standard five field cron expression (minute, hour,
+// day of month, month, day of week), one of the @yearly, @monthly, @weekly,
+// @daily and @hourly descriptors or "@every <duration>". The expression may be
+// prefixed with "CRON_TZ=<zone>" or "TZ=<zone>" to evaluate it in a time zone
+// other than the default location.
+func parseCronSpec(spec string, location *time.Location) (cronSchedule, error) {
+	spec = strings.TrimSpace(spec)
+	if strings.HasPrefix(spec, cronTimeZonePrefix) || strings.HasPrefix(spec, timeZonePrefix) {
+		fields := strings.SplitN(spec, space, 2)
+		zone := fields[0][strings.Index(fields[0], "=")+1:]
+		loaded, err := time.LoadLocation(zone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid time zone %q: %v", zone, err)
+		}
+		location = loaded
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("missing cron expression after %q", fields[0])
+		}
+		spec = strings.TrimSpace(fields[1])
+	}
+
+	if location == nil {
+		location = time.Local
+	}
+
+	if strings.HasPrefix(spec, everyDescriptor) {
+		interval, err := time.ParseDuration(strings.TrimSpace(spec[len(everyDescriptor):]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid interval in %q: %v", spec, err)
+		}
+		if interval <= 0 {
+			return nil, fmt.Errorf("interval in %q must be positive", spec)
+		}
+		return everySchedule{interval: interval}, nil
+	}
+
+	if expression, ok := cronDescriptors[strings.ToLower(spec)]; ok {
+		spec = expression
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("expected 5 fields in cron expression %q, found %d", spec, len(fields))
+	}
+
+	schedule := &fieldSchedule{location: location}
+	targets := []*uint64{&schedule.minute, &schedule.hour, &schedule.dom, &schedule.month, &schedule.dow}
+	for i, field := range []cronField{minuteField, hourField, domField, monthField, dowField} {
+		bits, err := field.parse(fields[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron expression %q: %v", spec, err)
+		}
+		*targets[i] = bits
+	}
+
+	// 7 is an alias for sunday
+	if schedule.dow&(1<<7) != 0 {
+		schedule.dow = schedule.dow&^(1<<7) | 1
+	}
+	return schedule, nil
+}
+
+// parse converts a comma separated list of values, ranges and steps into a bit
+// set. Values can be given by name for months and days of the week.
+func (f cronField) parse(expression string) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(expression, ",") {
+		rangeExpression, step := part, 1
+		if index := strings.Index(part, "/"); index >= 0 {
+			parsed, err := strconv.Atoi(part[index+1:])
+			if err != nil || parsed <= 0 {
+				return 0, fmt.Errorf("invalid step in %q", part)
+			}
+			rangeExpression, step = part[:index], parsed
+		}
+
+		var low, high int
+		switch {
+		case rangeExpression == star || rangeExpression == "?":
+			low, high = f.min, f.wildcardHigh()
+		case strings.Contains(rangeExpression, dash):
+			bounds := strings.SplitN(rangeExpression, dash, 2)
+			var err error
+			if low, err = f.value(bounds[0]); err != nil {
+				return 0, err
+			}
+			if high, err = f.value(bounds[1]); err != nil {
+				return 0, err
+			}
+		default:
+			value, err := f.value(rangeExpression)
+			if err != nil {
+				return 0, err
+			}
+			low, high = value, value
+			if step > 1 {
+				high = f.max
+			}
+		}
+
+		if low > high {
+			return 0, fmt.Errorf("invalid range in %q", part)
+		}
+		for value := low; value <= high; value += step {
+			bits |= 1 << uint(value)
+		}
+	}
+	return bits, nil
+}
+
+func (f cronField) value(expression string) (int, error) {
+	if value, ok := f.names[strings.ToLower(expression)]; ok {
+		return value, nil
+	}
+
+	value, err := strconv.Atoi(expression)
+	if err != nil || value < f.min || value > f.max {
+		return 0, fmt.Errorf("value %q is out of range [%d, %d]", expression, f.min, f.max)
+	}
+	return value, nil
+}
+
+func (s *fieldSchedule) next(t time.Time) time.Time {
+	t = t.In(s.location).Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(cronSearchYears, 0, 0)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = forward(t, time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, s.location))
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = forward(t, time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, s.location))
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = nextHour(t)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// forward returns the candidate if it is after t, and the next hour otherwise.
+// Midnight does not exist on some daylight saving changes, time.Date then
+// normalizes the candidate to a time that may not be after t.
+func forward(t time.Time, candidate time.Time) time.Time {
+	if candidate.After(t) {
+		return candidate
+	}
+	return nextHour(t)
+}
+
+// nextHour returns the start of the next hour, adding absolute time so that
+// hours skipped by daylight saving changes are stepped over
+func nextHour(t time.Time) time.Time {
+	return t.Add(time.Duration(60-t.Minute()) * time.Minute)
+}
+
+// dayMatches follows the usual cron semantics: when both the day of month and
+// the day of week are restricted, matching either of them is enough
+func (s *fieldSchedule) dayMatches(t time.Time) bool {
+	domMatches := s.dom&(1<<uint(t.Day())) != 0
+	dowMatches := s.dow&(1<<uint(t.Weekday())) != 0
+
+	allDays := s.dom == domField.all()
+	allWeekdays := s.dow == dowField.all()
+	switch {
+	case allDays && allWeekdays:
+		return true
+	case allDays:
+		return dowMatches
+	case allWeekdays:
+		return domMatches
+	default:
+		return domMatches || dowMatches
+	}
+}
+
+// all returns the bit set of a field matching every value
+func (f cronField) all() uint64 {
+	var bits uint64
+	for value := f.min; value <= f.wildcardHigh(); value++ {
+		bits |= 1 << uint(value)
+	}
+	return bits
+}
+
+func (f cronField) wildcardHigh() int {
+	if f.wildcardMax > 0 {
+		return f.wildcardMax
+	}
+	return f.max
+}
@@ -0,0 +1,53 @@
+package slacker
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCronNextAcrossDaylightSavingChanges(t *testing.T) {
+	tests := []struct {
+		zone     string
+		spec     string
+		from     string
+		expected string
+	}{
+		// clocks jump from 02:00 to 03:00 on 2026-03-08
+		{"America/New_York", "0 9 * * *", "2026-03-07 12:00", "2026-03-08T09:00:00-04:00"},
+		{"America/New_York", "30 2 * * *", "2026-03-07 12:00", "2026-03-09T02:30:00-04:00"},
+		// clocks go back from 02:00 to 01:00 on 2026-11-01
+		{"America/New_York", "0 9 * * *", "2026-10-31 12:00", "2026-11-01T09:00:00-05:00"},
+		// clocks jump from midnight to 01:00 on 2026-09-06
+		{"America/Santiago", "0 9 * * *", "2026-09-05 12:00", "2026-09-06T09:00:00-03:00"},
+		{"America/Santiago", "0 9 1 10 *", "2026-09-05 12:00", "2026-10-01T09:00:00-03:00"},
+	}
+
+	for _, test := range tests {
+		location, err := time.LoadLocation(test.zone)
+		if err != nil {
+			t.Skipf("time zone %s unavailable: %v", test.zone, err)
+		}
+
+		schedule, err := parseCronSpec(test.spec, location)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		from, err := time.ParseInLocation("2006-01-02 15:04", test.from, location)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		done := make(chan time.Time, 1)
+		go func() { done <- schedule.next(from) }()
+
+		select {
+		case next := <-done:
+			if got := next.Format(time.RFC3339); got != test.expected {
+				t.Errorf("%s in %s: expected %s, got %s", test.spec, test.zone, test.expected, got)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("%s in %s: next did not return", test.spec, test.zone)
+		}
+	}
+}
@@ -20,21 +20,134 @@ func WithBotInteractionMode(mode BotInteractionMode) ClientOption {
 	}
 }
 
+// WithValidationMode instructs Slacker on how to handle problems found in the
+// command table when Listen is called. Problems are only printed by default,
+// ValidationModeStrict makes Listen fail on errors.
+func WithValidationMode(mode ValidationMode) ClientOption {
+	return func(defaults *ClientDefaults) {
+		defaults.ValidationMode = mode
+	}
+}
+
+// WithStore sets the store handlers keep their state in, an in-memory store
+// is used by default
+func WithStore(store Store) ClientOption {
+	return func(defaults *ClientDefaults) {
+		defaults.Store = store
+	}
+}
+
+// WithEphemeralErrors makes errors reported through ReportError visible only
+// to the user who triggered the event, unless overridden per call
+func WithEphemeralErrors(ephemeral bool) ClientOption {
+	return func(defaults *ClientDefaults) {
+		defaults.EphemeralErrors = ephemeral
+	}
+}
+
+// WithEphemeralHelp makes the built-in help message visible only to the user
+// who requested it
+func WithEphemeralHelp(ephemeral bool) ClientOption {
+	return func(defaults *ClientDefaults) {
+		defaults.EphemeralHelp = ephemeral
+	}
+}
+
+// WithEphemeralUnauthorized makes the unauthorized error visible only to the
+// user who attempted the command
+func WithEphemeralUnauthorized(ephemeral bool) ClientOption {
+	return func(defaults *ClientDefaults) {
+		defaults.EphemeralUnauthorized = ephemeral
+	}
+}
+
+// WithLifecycleReactions marks the message that triggered a command with a
+// reaction while it runs and when it succeeds or fails, unless the command sets
+// its own reactions
+func WithLifecycleReactions(reactions *LifecycleReactions) ClientOption {
+	return func(defaults *ClientDefaults) {
+		defaults.LifecycleReactions = reactions
+	}
+}
+
+// WithOverflowPolicy instructs Slacker on how to deliver replies exceeding the
+// size limits of a Slack message, they are split into several messages by default
+func WithOverflowPolicy(policy OverflowPolicy) ClientOption {
+	return func(defaults *ClientDefaults) {
+		defaults.OverflowPolicy = policy
+	}
+}
+
+// WithReplyPlacement instructs Slacker on whether replies are threaded, unless
+// the command sets its own placement. Replies are posted where the command was
+// invoked by default.
+func WithReplyPlacement(placement ReplyPlacement) ClientOption {
+	return func(defaults *ClientDefaults) {
+		defaults.ReplyPlacement = placement
+	}
+}
+
+// WithTemplates sets the registry of message templates, which also overrides
+// the built-in messages
+func WithTemplates(templates *Templates) ClientOption {
+	return func(defaults *ClientDefaults) {
+		defaults.Templates = templates
+	}
+}
+
+// WithBuiltinHome publishes a home tab listing the commands and the recent
+// commands of the user, unless a handler is set with AppHomeOpened
+func WithBuiltinHome(enabled bool) ClientOption {
+	return func(defaults *ClientDefaults) {
+		defaults.BuiltinHome = enabled
+	}
+}
+
+// WithEditTracking matches edited messages again, updating the replies of the
+// bot to their previous version, and deletes the replies to deleted messages
+func WithEditTracking(enabled bool) ClientOption {
+	return func(defaults *ClientDefaults) {
+		defaults.EditTracking = enabled
+	}
+}
+
 // ClientDefaults configuration
 type ClientDefaults struct {
-	Debug   bool
-	BotMode BotInteractionMode
+	Debug                 bool
+	BotMode               BotInteractionMode
+	ValidationMode        ValidationMode
+	Store                 Store
+	EphemeralErrors       bool
+	EphemeralHelp         bool
+	EphemeralUnauthorized bool
+	LifecycleReactions    *LifecycleReactions
+	OverflowPolicy        OverflowPolicy
+	ReplyPlacement        ReplyPlacement
+	Templates             *Templates
+	BuiltinHome           bool
+	EditTracking          bool
 }
 
 func newClientDefaults(options ...ClientOption) *ClientDefaults {
 	config := &ClientDefaults{
-		Debug:   false,
-		BotMode: BotInteractionModeIgnoreAll,
+		Debug:          false,
+		BotMode:        BotInteractionModeIgnoreAll,
+		ValidationMode: ValidationModeWarn,
+		OverflowPolicy: OverflowPolicySplit,
+		ReplyPlacement: ReplyPlacementWhereInvoked,
 	}
 
 	for _, option := range options {
 		option(config)
 	}
+
+	if config.Store == nil {
+		config.Store = NewMemoryStore()
+	}
+
+	if config.Templates == nil {
+		config.Templates = NewTemplates()
+	}
 	return config
 }
 
@@ -55,26 +168,67 @@ func WithBlocks(blocks []slack.Block) ReplyOption {
 	}
 }
 
-// WithThreadReply specifies the reply to be inside a thread of the original message
+// WithThreadReply specifies the reply to be inside a thread of the original
+// message, regardless of the reply placement
 func WithThreadReply(useThread bool) ReplyOption {
 	return func(defaults *ReplyDefaults) {
 		defaults.ThreadResponse = useThread
 	}
 }
 
+// WithEphemeral specifies the reply to be visible only to the user who
+// triggered the event
+func WithEphemeral(ephemeral bool) ReplyOption {
+	return func(defaults *ReplyDefaults) {
+		defaults.Ephemeral = ephemeral
+	}
+}
+
+// WithReplaceOriginal specifies the reply to replace the message the event
+// originated from, for slash command and interaction responses sent through the
+// response URL
+func WithReplaceOriginal(replace bool) ReplyOption {
+	return func(defaults *ReplyDefaults) {
+		defaults.ReplaceOriginal = replace
+	}
+}
+
+// WithBroadcast specifies a reply posted in a thread to also be sent to the channel
+func WithBroadcast(broadcast bool) ReplyOption {
+	return func(defaults *ReplyDefaults) {
+		defaults.Broadcast = broadcast
+	}
+}
+
+// WithDMNotice specifies a direct message reply to leave a notice pointing the
+// user to it where the event was received
+func WithDMNotice(notice bool) ReplyOption {
+	return func(defaults *ReplyDefaults) {
+		defaults.DMNotice = notice
+	}
+}
+
 // ReplyDefaults configuration
 type ReplyDefaults struct {
-	Attachments    []slack.Attachment
-	Blocks         []slack.Block
-	ThreadResponse bool
+	Attachments     []slack.Attachment
+	Blocks          []slack.Block
+	ThreadResponse  bool
+	Ephemeral       bool
+	ReplaceOriginal bool
+	Broadcast       bool
+	DMNotice        bool
 }
 
 // NewReplyDefaults builds our ReplyDefaults from zero or more ReplyOption.
 func NewReplyDefaults(options ...ReplyOption) *ReplyDefaults {
 	config := &ReplyDefaults{
-		Attachments:    []slack.Attachment{},
-		Blocks:         []slack.Block{},
-		ThreadResponse: false,
+		Attachments:     []slack.Attachment{},
+		Blocks:          []slack.Block{},
+		ThreadResponse:  false,
+		Ephemeral:       false,
+		ReplaceOriginal: false,
+		Broadcast:       false,
+		DMNotice:        false,
 	}
 
 	for _, option := range options {
@@ -89,20 +243,92 @@ type ReportErrorOption func(*ReportErrorDefaults)
 // ReportErrorDefaults configuration
 type ReportErrorDefaults struct {
 	ThreadResponse bool
+	Ephemeral      bool
 }
 
-// WithThreadError specifies the reply to be inside a thread of the original message
+// WithThreadError specifies the reply to be inside a thread of the original
+// message, regardless of the reply placement
 func WithThreadError(useThread bool) ReportErrorOption {
 	return func(defaults *ReportErrorDefaults) {
 		defaults.ThreadResponse = useThread
 	}
 }
 
+// WithEphemeralError specifies the error to be visible only to the user who
+// triggered the event
+func WithEphemeralError(ephemeral bool) ReportErrorOption {
+	return func(defaults *ReportErrorDefaults) {
+		defaults.Ephemeral = ephemeral
+	}
+}
+
 // NewReportErrorDefaults builds our ReportErrorDefaults from zero or more
 // ReportErrorOption.
 func NewReportErrorDefaults(options ...ReportErrorOption) *ReportErrorDefaults {
 	config := &ReportErrorDefaults{
 		ThreadResponse: false,
+		Ephemeral:      false,
+	}
+
+	for _, option := range options {
+		option(config)
+	}
+	return config
+}
+
+// UploadOption an option for upload values
+type UploadOption func(*UploadDefaults)
+
+// WithFilename sets the name of the uploaded file
+func WithFilename(filename string) UploadOption {
+	return func(defaults *UploadDefaults) {
+		defaults.Filename = filename
+	}
+}
+
+// WithFiletype sets the type of the uploaded file, eg. "csv" or "diff", Slack
+// detects it from the content by default
+func WithFiletype(filetype string) UploadOption {
+	return func(defaults *UploadDefaults) {
+		defaults.Filetype = filetype
+	}
+}
+
+// WithTitle sets the title of the uploaded file
+func WithTitle(title string) UploadOption {
+	return func(defaults *UploadDefaults) {
+		defaults.Title = title
+	}
+}
+
+// WithInitialComment sets the message posted along with the uploaded file
+func WithInitialComment(comment string) UploadOption {
+	return func(defaults *UploadDefaults) {
+		defaults.InitialComment = comment
+	}
+}
+
+// WithThreadUpload specifies the file to be uploaded inside a thread of the
+// original message, regardless of the reply placement
+func WithThreadUpload(useThread bool) UploadOption {
+	return func(defaults *UploadDefaults) {
+		defaults.ThreadResponse = useThread
+	}
+}
+
+// UploadDefaults configuration
+type UploadDefaults struct {
+	Filename       string
+	Filetype       string
+	Title          string
+	InitialComment string
+	ThreadResponse bool
+}
+
+// NewUploadDefaults builds our UploadDefaults from zero or more UploadOption.
+func NewUploadDefaults(options ...UploadOption) *UploadDefaults {
+	config := &UploadDefaults{
+		ThreadResponse: false,
 	}
 
 	for _, option := range options {
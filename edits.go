@@ -0,0 +1,170 @@
+package slacker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/slackevents"
+)
+
+const (
+	messageChangedSubType = "message_changed"
+	messageDeletedSubType = "message_deleted"
+	trackedRepliesKey     = "replies/"
+	trackedRepliesTTL     = 24 * time.Hour
+)
+
+// trackedReply refers to a reply posted to a message
+type trackedReply struct {
+	Channel   string `json:"channel"`
+	TimeStamp string `json:"ts"`
+}
+
+// replyTracker collects the replies posted while handling a message. When the
+// message was edited, the replies to its previous version are updated in order
+// instead of posting new ones.
+type replyTracker struct {
+	mutex    sync.Mutex
+	previous []trackedReply
+	replies  []trackedReply
+}
+
+type replyTrackerContextKey struct{}
+
+func withReplyTracker(ctx context.Context, tracker *replyTracker) context.Context {
+	return context.WithValue(ctx, replyTrackerContextKey{}, tracker)
+}
+
+// replyTrackerFromContext returns the tracker of the message being handled,
+// nil if edit tracking is disabled
+func replyTrackerFromContext(ctx context.Context) *replyTracker {
+	if ctx == nil {
+		return nil
+	}
+	tracker, _ := ctx.Value(replyTrackerContextKey{}).(*replyTracker)
+	return tracker
+}
+
+// reuse returns the next reply to the previous version of the message
+func (t *replyTracker) reuse() (trackedReply, bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if len(t.previous) == 0 {
+		return trackedReply{}, false
+	}
+	reply := t.previous[0]
+	t.previous = t.previous[1:]
+	return reply, true
+}
+
+func (t *replyTracker) add(channel string, timestamp string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.replies = append(t.replies, trackedReply{Channel: channel, TimeStamp: timestamp})
+}
+
+// messageSubType returns the subtype of a message event, eg. "message_changed"
+func messageSubType(ev *MessageEvent) string {
+	if message, ok := ev.Data.(*slackevents.MessageEvent); ok {
+		return message.SubType
+	}
+	return empty
+}
+
+// isEdit indicates if a message_changed event changed the text of the message,
+// rather than eg. adding an unfurl or a thread reply
+func isEdit(ev *MessageEvent) bool {
+	message, ok := ev.Data.(*slackevents.MessageEvent)
+	if !ok || !message.IsEdited() {
+		return false
+	}
+	return message.PreviousMessage == nil || message.PreviousMessage.Text != message.Message.Text
+}
+
+// trackReplies starts tracking the replies to the message, loading the replies
+// to its previous version when it was edited
+func (s *Slacker) trackReplies(ev *MessageEvent) *replyTracker {
+	tracker := &replyTracker{}
+	if messageSubType(ev) == messageChangedSubType {
+		tracker.previous = s.trackedReplies(ev)
+	}
+	return tracker
+}
+
+// saveReplies remembers the replies to the message. Replies to its previous
+// version that were not reused are deleted. Nothing changes if the bot did not
+// reply, eg. because the edited message no longer matches a command.
+func (s *Slacker) saveReplies(ev *MessageEvent, tracker *replyTracker) {
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+
+	if len(tracker.replies) == 0 {
+		return
+	}
+
+	for _, reply := range tracker.previous {
+		if _, _, err := s.client.DeleteMessage(reply.Channel, reply.TimeStamp); err != nil {
+			fmt.Printf("unable to delete reply %s: %v\n", reply.TimeStamp, err)
+		}
+	}
+	tracker.previous = nil
+
+	data, err := json.Marshal(tracker.replies)
+	if err != nil {
+		return
+	}
+	if err := s.scopedStore(StoreScopeChannel, ev).Set(trackedRepliesKey+ev.TimeStamp, string(data), trackedRepliesTTL); err != nil {
+		fmt.Printf("unable to track replies to %s: %v\n", ev.TimeStamp, err)
+	}
+}
+
+// deleteReplies deletes the replies to a deleted message
+func (s *Slacker) deleteReplies(ev *MessageEvent) {
+	for _, reply := range s.trackedReplies(ev) {
+		if _, _, err := s.client.DeleteMessage(reply.Channel, reply.TimeStamp); err != nil {
+			fmt.Printf("unable to delete reply %s: %v\n", reply.TimeStamp, err)
+		}
+	}
+
+	if err := s.scopedStore(StoreScopeChannel, ev).Delete(trackedRepliesKey + ev.TimeStamp); err != nil {
+		fmt.Printf("unable to forget replies to %s: %v\n", ev.TimeStamp, err)
+	}
+}
+
+func (s *Slacker) trackedReplies(ev *MessageEvent) []trackedReply {
+	value, found, err := s.scopedStore(StoreScopeChannel, ev).Get(trackedRepliesKey + ev.TimeStamp)
+	if err != nil || !found {
+		return nil
+	}
+
+	var replies []trackedReply
+	if err := json.Unmarshal([]byte(value), &replies); err != nil {
+		return nil
+	}
+	return replies
+}
+
+// postTracked posts a reply to a tracked message, or updates the matching
+// reply to its previous version in place
+func postTracked(client *slack.Client, tracker *replyTracker, channel string, msg *message, options []slack.MsgOption) (string, string, error) {
+	if reply, ok := tracker.reuse(); ok {
+		_, timestamp, _, err := client.UpdateMessage(reply.Channel, reply.TimeStamp, msg.options()...)
+		if err == nil {
+			tracker.add(reply.Channel, timestamp)
+			return reply.Channel, timestamp, nil
+		}
+		fmt.Printf("unable to update reply %s, posting a new one: %v\n", reply.TimeStamp, err)
+	}
+
+	channel, timestamp, err := client.PostMessage(channel, options...)
+	if err == nil {
+		tracker.add(channel, timestamp)
+	}
+	return channel, timestamp, err
+}
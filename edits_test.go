@@ -0,0 +1,90 @@
+package slacker
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/slackevents"
+)
+
+// fakeSlack answers the Web API methods used to post, update and delete
+// messages and records the calls
+type fakeSlack struct {
+	mutex sync.Mutex
+	calls []string
+	posts int
+}
+
+func (f *fakeSlack) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	r.ParseForm()
+	method := strings.TrimPrefix(r.URL.Path, "/")
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	switch method {
+	case "chat.postMessage":
+		f.posts++
+		ts := fmt.Sprintf("2000.%06d", f.posts)
+		f.calls = append(f.calls, method+" "+r.Form.Get("text"))
+		fmt.Fprintf(w, `{"ok":true,"channel":%q,"ts":%q}`, r.Form.Get("channel"), ts)
+	case "chat.update":
+		f.calls = append(f.calls, method+" "+r.Form.Get("ts")+" "+r.Form.Get("text"))
+		fmt.Fprintf(w, `{"ok":true,"channel":%q,"ts":%q}`, r.Form.Get("channel"), r.Form.Get("ts"))
+	case "chat.delete":
+		f.calls = append(f.calls, method+" "+r.Form.Get("ts"))
+		fmt.Fprintf(w, `{"ok":true,"channel":%q,"ts":%q}`, r.Form.Get("channel"), r.Form.Get("ts"))
+	default:
+		fmt.Fprint(w, `{"ok":false,"error":"unknown_method"}`)
+	}
+}
+
+func TestEditedMessageUpdatesReply(t *testing.T) {
+	fake := &fakeSlack{}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	s := NewClient("xoxb-token", "xapp-token", WithEditTracking(true))
+	s.client = slack.New("xoxb-token", slack.OptionAPIURL(server.URL+"/"))
+	s.Command("deploy <service>", &CommandDefinition{
+		Handler: func(botCtx BotContext, request Request, response ResponseWriter) {
+			response.Reply("Deploying " + request.Param("service"))
+		},
+	})
+
+	original := &slackevents.MessageEvent{Type: "message", Channel: "C1", User: "U1", Text: "deploy sevrice", TimeStamp: "1000.000001"}
+	s.handleMessageEvent(context.Background(), original, nil)
+
+	edited := &slackevents.MessageEvent{
+		Type:            "message",
+		SubType:         messageChangedSubType,
+		Channel:         "C1",
+		Message:         &slackevents.MessageEvent{User: "U1", Text: "deploy service", TimeStamp: "1000.000001", Edited: &slackevents.Edited{User: "U1"}},
+		PreviousMessage: &slackevents.MessageEvent{User: "U1", Text: "deploy sevrice", TimeStamp: "1000.000001"},
+	}
+	s.handleMessageEvent(context.Background(), edited, nil)
+
+	deleted := &slackevents.MessageEvent{
+		Type:            "message",
+		SubType:         messageDeletedSubType,
+		Channel:         "C1",
+		PreviousMessage: &slackevents.MessageEvent{User: "U1", Text: "deploy service", TimeStamp: "1000.000001"},
+	}
+	s.handleMessageEvent(context.Background(), deleted, nil)
+
+	expected := []string{
+		"chat.postMessage Deploying sevrice",
+		"chat.update 2000.000001 Deploying service",
+		"chat.delete 2000.000001",
+	}
+	if strings.Join(fake.calls, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("expected calls:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(fake.calls, "\n"))
+	}
+}
@@ -0,0 +1,189 @@
+package slacker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/slackevents"
+)
+
+// Events API event types with typed registration helpers
+const (
+	EventReactionAdded       = "reaction_added"
+	EventReactionRemoved     = "reaction_removed"
+	EventMemberJoinedChannel = "member_joined_channel"
+	EventMemberLeftChannel   = "member_left_channel"
+	EventChannelCreated      = "channel_created"
+	EventFileShared          = "file_shared"
+	EventTeamJoin            = "team_join"
+	EventPinAdded            = "pin_added"
+	EventPinRemoved          = "pin_removed"
+)
+
+// eventScopes lists the bot scopes needed to receive an event type
+var eventScopes = map[string][]string{
+	EventReactionAdded:       {"reactions:read"},
+	EventReactionRemoved:     {"reactions:read"},
+	EventMemberJoinedChannel: {"channels:read", "groups:read"},
+	EventMemberLeftChannel:   {"channels:read", "groups:read"},
+	EventChannelCreated:      {"channels:read"},
+	EventFileShared:          {"files:read"},
+	EventTeamJoin:            {"users:read"},
+	EventPinAdded:            {"pins:read"},
+	EventPinRemoved:          {"pins:read"},
+}
+
+// EventHandler handles an Events API event. The event is the value parsed by
+// slackevents, eg. *slackevents.ReactionAddedEvent for "reaction_added".
+type EventHandler func(botCtx BotContext, event interface{})
+
+// OnEvent registers a handler for an Events API event type, eg.
+// "reaction_added". Several handlers can be registered for the same type, they
+// run in the order they were registered.
+func (s *Slacker) OnEvent(eventType string, handler EventHandler) {
+	if s.eventHandlers == nil {
+		s.eventHandlers = make(map[string][]EventHandler)
+	}
+	s.eventHandlers[eventType] = append(s.eventHandlers[eventType], handler)
+}
+
+// OnReactionAdded handles emoji reactions being added to messages
+func (s *Slacker) OnReactionAdded(handler func(botCtx BotContext, event *slackevents.ReactionAddedEvent)) {
+	s.OnEvent(EventReactionAdded, func(botCtx BotContext, event interface{}) {
+		if ev, ok := event.(*slackevents.ReactionAddedEvent); ok {
+			handler(botCtx, ev)
+		}
+	})
+}
+
+// OnReactionRemoved handles emoji reactions being removed from messages
+func (s *Slacker) OnReactionRemoved(handler func(botCtx BotContext, event *slackevents.ReactionRemovedEvent)) {
+	s.OnEvent(EventReactionRemoved, func(botCtx BotContext, event interface{}) {
+		if ev, ok := event.(*slackevents.ReactionRemovedEvent); ok {
+			handler(botCtx, ev)
+		}
+	})
+}
+
+// OnMemberJoined handles users joining a channel
+func (s *Slacker) OnMemberJoined(handler func(botCtx BotContext, event *slackevents.MemberJoinedChannelEvent)) {
+	s.OnEvent(EventMemberJoinedChannel, func(botCtx BotContext, event interface{}) {
+		if ev, ok := event.(*slackevents.MemberJoinedChannelEvent); ok {
+			handler(botCtx, ev)
+		}
+	})
+}
+
+// OnMemberLeft handles users leaving a channel
+func (s *Slacker) OnMemberLeft(handler func(botCtx BotContext, event *slackevents.MemberLeftChannelEvent)) {
+	s.OnEvent(EventMemberLeftChannel, func(botCtx BotContext, event interface{}) {
+		if ev, ok := event.(*slackevents.MemberLeftChannelEvent); ok {
+			handler(botCtx, ev)
+		}
+	})
+}
+
+// OnChannelCreated handles new public channels
+func (s *Slacker) OnChannelCreated(handler func(botCtx BotContext, event *slackevents.ChannelCreatedEvent)) {
+	s.OnEvent(EventChannelCreated, func(botCtx BotContext, event interface{}) {
+		if ev, ok := event.(*slackevents.ChannelCreatedEvent); ok {
+			handler(botCtx, ev)
+		}
+	})
+}
+
+// OnFileShared handles files being shared in channels the bot is in
+func (s *Slacker) OnFileShared(handler func(botCtx BotContext, event *slack.FileSharedEvent)) {
+	s.OnEvent(EventFileShared, func(botCtx BotContext, event interface{}) {
+		if ev, ok := event.(*slack.FileSharedEvent); ok {
+			handler(botCtx, ev)
+		}
+	})
+}
+
+// OnTeamJoin handles new members of the workspace
+func (s *Slacker) OnTeamJoin(handler func(botCtx BotContext, event *slackevents.TeamJoinEvent)) {
+	s.OnEvent(EventTeamJoin, func(botCtx BotContext, event interface{}) {
+		if ev, ok := event.(*slackevents.TeamJoinEvent); ok {
+			handler(botCtx, ev)
+		}
+	})
+}
+
+// OnPinAdded handles items being pinned to a channel
+func (s *Slacker) OnPinAdded(handler func(botCtx BotContext, event *slackevents.PinAddedEvent)) {
+	s.OnEvent(EventPinAdded, func(botCtx BotContext, event interface{}) {
+		if ev, ok := event.(*slackevents.PinAddedEvent); ok {
+			handler(botCtx, ev)
+		}
+	})
+}
+
+// OnPinRemoved handles items being unpinned from a channel
+func (s *Slacker) OnPinRemoved(handler func(botCtx BotContext, event *slackevents.PinRemovedEvent)) {
+	s.OnEvent(EventPinRemoved, func(botCtx BotContext, event interface{}) {
+		if ev, ok := event.(*slackevents.PinRemovedEvent); ok {
+			handler(botCtx, ev)
+		}
+	})
+}
+
+// dispatchEvent runs the handlers registered for the event type, it returns
+// false if there are none
+func (s *Slacker) dispatchEvent(ctx context.Context, eventType string, data interface{}) bool {
+	handlers := s.eventHandlers[eventType]
+	if len(handlers) == 0 {
+		return false
+	}
+
+	ev := newEventsAPIMessageEvent(eventType, data)
+	botCtx := s.botContextConstructor(withSlacker(ctx, s), s.client, s.socketModeClient, ev)
+
+	go func() {
+		for _, handler := range handlers {
+			s.runEventHandler(eventType, handler, botCtx, data)
+		}
+	}()
+	return true
+}
+
+func (s *Slacker) runEventHandler(eventType string, handler EventHandler, botCtx BotContext, data interface{}) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.reportError(fmt.Errorf("handler of event %q panicked: %v", eventType, r))
+		}
+	}()
+	handler(botCtx, data)
+}
+
+// newEventsAPIMessageEvent describes an Events API event with the user,
+// channel and message it concerns, when it has them
+func newEventsAPIMessageEvent(eventType string, data interface{}) *MessageEvent {
+	ev := &MessageEvent{Type: eventType, Data: data}
+
+	switch event := data.(type) {
+	case *slackevents.ReactionAddedEvent:
+		ev.User, ev.Channel, ev.TimeStamp = event.User, event.Item.Channel, event.Item.Timestamp
+	case *slackevents.ReactionRemovedEvent:
+		ev.User, ev.Channel, ev.TimeStamp = event.User, event.Item.Channel, event.Item.Timestamp
+	case *slackevents.MemberJoinedChannelEvent:
+		ev.User, ev.Channel = event.User, event.Channel
+	case *slackevents.MemberLeftChannelEvent:
+		ev.User, ev.Channel = event.User, event.Channel
+	case *slackevents.ChannelCreatedEvent:
+		ev.User, ev.Channel = event.Channel.Creator, event.Channel.ID
+	case *slackevents.TeamJoinEvent:
+		if event.User != nil {
+			ev.User = event.User.ID
+		}
+	case *slackevents.PinAddedEvent:
+		ev.User, ev.Channel = event.User, event.Channel
+	case *slackevents.PinRemovedEvent:
+		ev.User, ev.Channel = event.User, event.Channel
+	case *slackevents.LinkSharedEvent:
+		ev.User, ev.Channel = event.User, event.Channel
+		ev.TimeStamp, ev.ThreadTimeStamp = event.MessageTimeStamp, event.ThreadTimeStamp
+	}
+	return ev
+}
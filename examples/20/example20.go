@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/sdslabs/slacker"
+)
+
+func main() {
+	bot := slacker.NewClient(os.Getenv("SLACK_BOT_TOKEN"), os.Getenv("SLACK_APP_TOKEN"))
+
+	bot.Command("deploy <service>", &slacker.CommandDefinition{
+		Description: "Deploy a service, only from ops channels and never in threads",
+		Examples:    []string{"deploy api"},
+		ChannelPolicy: &slacker.ChannelPolicy{
+			IncludeChannelNames: []string{"ops-.*"},
+			ExcludeChannelNames: []string{"ops-archive"},
+			ConversationTypes:   []slacker.ConversationType{slacker.ConversationTypePublic, slacker.ConversationTypePrivate},
+			ThreadMode:          slacker.ThreadModeTopLevelOnly,
+		},
+		Handler: func(botCtx slacker.BotContext, request slacker.Request, response slacker.ResponseWriter) {
+			response.Reply("Deploying " + request.Param("service"))
+		},
+	})
+
+	bot.Command("whoami", &slacker.CommandDefinition{
+		Description: "Only answers in direct messages",
+		ChannelPolicy: &slacker.ChannelPolicy{
+			ConversationTypes: []slacker.ConversationType{slacker.ConversationTypeDirect},
+		},
+		Handler: func(botCtx slacker.BotContext, request slacker.Request, response slacker.ResponseWriter) {
+			response.Reply("You are " + botCtx.Event().UserName)
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := bot.Listen(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/sdslabs/slacker"
+)
+
+var environments = []string{"staging", "production"}
+
+func main() {
+	bot := slacker.NewClient(os.Getenv("SLACK_BOT_TOKEN"), os.Getenv("SLACK_APP_TOKEN"))
+
+	// Ask blocks the handler until the same user replies in the same thread or DM
+	bot.Command("deploy <service>", &slacker.CommandDefinition{
+		Description: "Deploy a service to an environment",
+		Examples:    []string{"deploy api"},
+		Handler: func(botCtx slacker.BotContext, request slacker.Request, response slacker.ResponseWriter) {
+			service := request.Param("service")
+
+			for {
+				reply, err := slacker.Ask(response, "Which environment? ("+strings.Join(environments, ", ")+")", time.Minute)
+				if err != nil {
+					response.ReportError(err)
+					return
+				}
+
+				environment := strings.TrimSpace(reply.Text)
+				if contains(environments, environment) {
+					response.Reply(fmt.Sprintf("Deploying %s to %s", service, environment))
+					return
+				}
+				response.Reply(fmt.Sprintf("%q is not an environment I know", environment))
+			}
+		},
+	})
+
+	// Steps keep the handler free and can branch into further steps
+	bot.Command("feedback", &slacker.CommandDefinition{
+		Description: "Leave some feedback",
+		Handler: func(botCtx slacker.BotContext, request slacker.Request, response slacker.ResponseWriter) {
+			response.Reply("How would you rate the bot from 1 to 5?")
+
+			bot.Await(slacker.NewConversationKey(botCtx.Event()), &slacker.ConversationStep{
+				Timeout: 2 * time.Minute,
+				Validate: func(text string) error {
+					if !contains([]string{"1", "2", "3", "4", "5"}, strings.TrimSpace(text)) {
+						return fmt.Errorf("please answer with a number from 1 to 5")
+					}
+					return nil
+				},
+				Handler: func(botCtx slacker.BotContext, request slacker.Request, response slacker.ResponseWriter) {
+					if strings.TrimSpace(botCtx.Event().Text) == "5" {
+						response.Reply("Thanks!")
+						return
+					}
+
+					response.Reply("What could be better?")
+					bot.Await(slacker.NewConversationKey(botCtx.Event()), &slacker.ConversationStep{
+						Handler: func(botCtx slacker.BotContext, request slacker.Request, response slacker.ResponseWriter) {
+							response.Reply("Noted, thank you!")
+						},
+					})
+				},
+				OnTimeout: func(key slacker.ConversationKey) {
+					response.Reply("Maybe next time!")
+				},
+			})
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := bot.Listen(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func contains(list []string, element string) bool {
+	for _, value := range list {
+		if value == element {
+			return true
+		}
+	}
+	return false
+}
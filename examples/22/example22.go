@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/sdslabs/slacker"
+)
+
+func main() {
+	store, err := slacker.NewFileStore("slacker-store.json")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	bot := slacker.NewClient(os.Getenv("SLACK_BOT_TOKEN"), os.Getenv("SLACK_APP_TOKEN"), slacker.WithStore(store))
+
+	bot.Command("remember my default env <env>", &slacker.CommandDefinition{
+		Description: "Remember your default environment",
+		Examples:    []string{"remember my default env staging"},
+		Handler: func(botCtx slacker.BotContext, request slacker.Request, response slacker.ResponseWriter) {
+			err := slacker.StoreFor(botCtx, slacker.StoreScopeUser).Set("env", request.Param("env"), 0)
+			if err != nil {
+				response.ReportError(err)
+				return
+			}
+			response.Reply("Got it!")
+		},
+	})
+
+	bot.Command("what is my default env", &slacker.CommandDefinition{
+		Description: "Show your default environment",
+		Handler: func(botCtx slacker.BotContext, request slacker.Request, response slacker.ResponseWriter) {
+			env, found, err := slacker.StoreFor(botCtx, slacker.StoreScopeUser).Get("env")
+			switch {
+			case err != nil:
+				response.ReportError(err)
+			case !found:
+				response.Reply("You have no default environment yet")
+			default:
+				response.Reply("Your default environment is " + env)
+			}
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err = bot.Listen(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+
+	"github.com/sdslabs/slacker"
+)
+
+func main() {
+	bot := slacker.NewClient(os.Getenv("SLACK_BOT_TOKEN"), os.Getenv("SLACK_APP_TOKEN"))
+
+	bot.Err(func(err string) {
+		log.Println(err)
+	})
+
+	// Every weekday at 09:30 in Kolkata
+	err := bot.Schedule("CRON_TZ=Asia/Kolkata 30 9 * * MON-FRI", &slacker.JobDefinition{
+		Description: "Standup reminder",
+		Channel:     os.Getenv("SLACK_STANDUP_CHANNEL"),
+		Handler: func(botCtx slacker.BotContext, response slacker.ResponseWriter) error {
+			return response.Reply("Time for standup! :wave:")
+		},
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = bot.Schedule("@every 1h", &slacker.JobDefinition{
+		Description: "Hourly report",
+		Channel:     os.Getenv("SLACK_REPORT_CHANNEL"),
+		Handler: func(botCtx slacker.BotContext, response slacker.ResponseWriter) error {
+			return response.Reply("Report generated at " + time.Now().Format(time.Kitchen))
+		},
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err = bot.Listen(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
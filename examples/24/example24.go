@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+
+	"github.com/sdslabs/slacker"
+)
+
+func main() {
+	bot := slacker.NewClient(
+		os.Getenv("SLACK_BOT_TOKEN"),
+		os.Getenv("SLACK_APP_TOKEN"),
+		slacker.WithEphemeralHelp(true),
+		slacker.WithEphemeralErrors(true),
+		slacker.WithEphemeralUnauthorized(true),
+	)
+
+	bot.Command("my token", &slacker.CommandDefinition{
+		Description: "Show your personal token, only to you",
+		Handler: func(botCtx slacker.BotContext, request slacker.Request, response slacker.ResponseWriter) {
+			response.Reply("Your token is `s3cr3t`", slacker.WithEphemeral(true))
+		},
+	})
+
+	bot.Command("fail", &slacker.CommandDefinition{
+		Description: "Report an error to everyone in the channel",
+		Handler: func(botCtx slacker.BotContext, request slacker.Request, response slacker.ResponseWriter) {
+			response.ReportError(errors.New("everybody should know"), slacker.WithEphemeralError(false))
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := bot.Listen(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
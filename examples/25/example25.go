@@ -0,0 +1,41 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+
+	"github.com/sdslabs/slacker"
+)
+
+// Register the slash command `/status` in your Slack app for this example
+func main() {
+	bot := slacker.NewClient(os.Getenv("SLACK_BOT_TOKEN"), os.Getenv("SLACK_APP_TOKEN"))
+
+	bot.Command("status", &slacker.CommandDefinition{
+		Description: "Check the status of our services",
+		Handler: func(botCtx slacker.BotContext, request slacker.Request, response slacker.ResponseWriter) {
+			if !botCtx.Event().IsSlashCommand() {
+				response.Reply("All services are up")
+				return
+			}
+
+			// Sent within the acknowledgement, only visible to the user
+			response.Reply("Checking services...", slacker.WithEphemeral(true))
+
+			time.Sleep(5 * time.Second)
+
+			// Sent through the response URL, replacing the message above
+			response.Reply("All services are up", slacker.WithEphemeral(true), slacker.WithReplaceOriginal(true))
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := bot.Listen(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+
+	"github.com/sdslabs/slacker"
+)
+
+func main() {
+	bot := slacker.NewClient(os.Getenv("SLACK_BOT_TOKEN"), os.Getenv("SLACK_APP_TOKEN"))
+
+	bot.Command("deploy <service>", &slacker.CommandDefinition{
+		Description: "Deploy a service",
+		Examples:    []string{"deploy api"},
+		Handler: func(botCtx slacker.BotContext, request slacker.Request, response slacker.ResponseWriter) {
+			service := request.StringParam("service", "")
+
+			status, err := slacker.ReplyWithHandle(response, "Deploying "+service+"...")
+			if err != nil {
+				response.ReportError(err)
+				return
+			}
+
+			time.Sleep(5 * time.Second)
+
+			status.Update("Deployed " + service + " :white_check_mark:")
+			status.AddReaction("rocket")
+
+			if link, err := status.Permalink(); err == nil {
+				status.ReplyInThread("Deployment log: " + link)
+			}
+		},
+	})
+
+	bot.Command("ping", &slacker.CommandDefinition{
+		Description: "Check the bot is alive with a short lived message",
+		Handler: func(botCtx slacker.BotContext, request slacker.Request, response slacker.ResponseWriter) {
+			pong, err := slacker.ReplyWithHandle(response, "pong")
+			if err != nil {
+				return
+			}
+
+			time.Sleep(10 * time.Second)
+			pong.Delete()
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := bot.Listen(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
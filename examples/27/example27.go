@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"time"
+
+	"github.com/sdslabs/slacker"
+)
+
+func main() {
+	bot := slacker.NewClient(
+		os.Getenv("SLACK_BOT_TOKEN"),
+		os.Getenv("SLACK_APP_TOKEN"),
+		slacker.WithLifecycleReactions(&slacker.LifecycleReactions{
+			Started:   "eyes",
+			Succeeded: "white_check_mark",
+			Failed:    "x",
+		}),
+	)
+
+	bot.Command("backup", &slacker.CommandDefinition{
+		Description: "Back up the database",
+		Handler: func(botCtx slacker.BotContext, request slacker.Request, response slacker.ResponseWriter) {
+			time.Sleep(5 * time.Second)
+			response.Reply("Backup complete")
+		},
+	})
+
+	bot.Command("restore", &slacker.CommandDefinition{
+		Description: "Restore the database",
+		Handler: func(botCtx slacker.BotContext, request slacker.Request, response slacker.ResponseWriter) {
+			response.ReportError(errors.New("no backup available"))
+		},
+	})
+
+	bot.Command("like", &slacker.CommandDefinition{
+		Description: "React to your message",
+		// Disable the lifecycle reactions for this command
+		LifecycleReactions: &slacker.LifecycleReactions{},
+		Handler: func(botCtx slacker.BotContext, request slacker.Request, response slacker.ResponseWriter) {
+			slacker.React(response, "heart")
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := bot.Listen(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
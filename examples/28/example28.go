@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/exec"
+
+	"github.com/sdslabs/slacker"
+)
+
+func main() {
+	bot := slacker.NewClient(os.Getenv("SLACK_BOT_TOKEN"), os.Getenv("SLACK_APP_TOKEN"))
+
+	bot.Command("diff", &slacker.CommandDefinition{
+		Description: "Upload the working tree changes",
+		Handler: func(botCtx slacker.BotContext, request slacker.Request, response slacker.ResponseWriter) {
+			output, err := exec.Command("git", "diff").Output()
+			if err != nil {
+				response.ReportError(err)
+				return
+			}
+
+			_, err = slacker.Upload(response, slacker.UploadBytes(output),
+				slacker.WithFilename("changes.diff"),
+				slacker.WithFiletype("diff"),
+				slacker.WithInitialComment("Here are the current changes"),
+				slacker.WithThreadUpload(true),
+			)
+			if err != nil {
+				response.ReportError(err)
+			}
+		},
+	})
+
+	bot.Command("logs", &slacker.CommandDefinition{
+		Description: "Upload the application logs",
+		Handler: func(botCtx slacker.BotContext, request slacker.Request, response slacker.ResponseWriter) {
+			_, err := slacker.Upload(response, slacker.UploadPath("/var/log/app.log"), slacker.WithTitle("Application logs"))
+			if err != nil {
+				response.ReportError(err)
+			}
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := bot.Listen(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
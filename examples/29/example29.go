@@ -0,0 +1,43 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/sdslabs/slacker"
+)
+
+func main() {
+	// Text that does not fit in a message is uploaded as a snippet, by
+	// default it is split across several messages instead
+	bot := slacker.NewClient(
+		os.Getenv("SLACK_BOT_TOKEN"),
+		os.Getenv("SLACK_APP_TOKEN"),
+		slacker.WithOverflowPolicy(slacker.OverflowPolicySnippet),
+	)
+
+	bot.Command("count <number>", &slacker.CommandDefinition{
+		Description: "Count up to a number",
+		Examples:    []string{"count 100000"},
+		Handler: func(botCtx slacker.BotContext, request slacker.Request, response slacker.ResponseWriter) {
+			number := request.IntegerParam("number", 10)
+
+			var lines []string
+			for i := 1; i <= number; i++ {
+				lines = append(lines, fmt.Sprint(i))
+			}
+			response.Reply("```\n" + strings.Join(lines, "\n") + "\n```")
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := bot.Listen(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/sdslabs/slacker"
+)
+
+func main() {
+	// Replies are posted where the command was invoked by default
+	bot := slacker.NewClient(
+		os.Getenv("SLACK_BOT_TOKEN"),
+		os.Getenv("SLACK_APP_TOKEN"),
+		slacker.WithReplyPlacement(slacker.ReplyPlacementWhereInvoked),
+	)
+
+	bot.Command("ping", &slacker.CommandDefinition{
+		Description: "Answers in the thread it was asked in",
+		Handler: func(botCtx slacker.BotContext, request slacker.Request, response slacker.ResponseWriter) {
+			response.Reply("pong")
+		},
+	})
+
+	bot.Command("incident <title>", &slacker.CommandDefinition{
+		Description:    "Start an incident thread",
+		ReplyPlacement: slacker.ReplyPlacementAlwaysThread,
+		Handler: func(botCtx slacker.BotContext, request slacker.Request, response slacker.ResponseWriter) {
+			title := request.StringParam("title", "")
+			response.Reply("Incident started: " + title)
+			response.Reply("Everyone, please join the incident thread", slacker.WithBroadcast(true))
+		},
+	})
+
+	bot.Command("announce <text>", &slacker.CommandDefinition{
+		Description:    "Announce in the channel",
+		ReplyPlacement: slacker.ReplyPlacementNeverThread,
+		Handler: func(botCtx slacker.BotContext, request slacker.Request, response slacker.ResponseWriter) {
+			response.Reply(request.StringParam("text", ""))
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := bot.Listen(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+
+	"github.com/sdslabs/slacker"
+)
+
+func main() {
+	bot := slacker.NewClient(os.Getenv("SLACK_BOT_TOKEN"), os.Getenv("SLACK_APP_TOKEN"))
+
+	bot.Command("migrate", &slacker.CommandDefinition{
+		Description: "Migrate the database",
+		Handler: func(botCtx slacker.BotContext, request slacker.Request, response slacker.ResponseWriter) {
+			progress, err := slacker.Progress(response, "Database migration")
+			if err != nil {
+				response.ReportError(err)
+				return
+			}
+
+			progress.Step("Taking a backup")
+			time.Sleep(3 * time.Second)
+
+			progress.Step("Migrating tables")
+			for i := 1; i <= 10; i++ {
+				progress.Progress(i*10, "migrating table "+string(rune('a'+i-1)))
+				time.Sleep(time.Second)
+			}
+
+			// The status message is finished once the handler returns, or
+			// when calling Done or Fail
+			progress.Done("All tables are up to date")
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := bot.Listen(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
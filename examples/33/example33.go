@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/sdslabs/slacker"
+	"github.com/sdslabs/slacker/blocks"
+)
+
+func main() {
+	bot := slacker.NewClient(os.Getenv("SLACK_BOT_TOKEN"), os.Getenv("SLACK_APP_TOKEN"))
+
+	bot.Command("deploy <service>", &slacker.CommandDefinition{
+		Description: "Ask for approval to deploy a service",
+		Examples:    []string{"deploy api"},
+		BlockID:     "deploy",
+		Handler: func(botCtx slacker.BotContext, request slacker.Request, response slacker.ResponseWriter) {
+			service := request.StringParam("service", "")
+
+			layout, err := blocks.New().
+				Header("Deployment of "+service).
+				Section(blocks.Markdown("Deploy *"+service+"* to an environment?"),
+					blocks.WithAccessory(blocks.Select("environment", "Environment",
+						blocks.Option("Staging", "staging"),
+						blocks.Option("Production", "production"),
+					).Initial("staging")),
+				).
+				Fields(blocks.Markdown("*Requested by*\n<@"+botCtx.Event().User+">"), blocks.Markdown("*Service*\n"+service)).
+				Divider().
+				Actions("deploy",
+					blocks.Button("approve", "Approve", service).Primary(),
+					blocks.Button("reject", "Reject", service).Danger(),
+				).
+				Context(blocks.Markdown("Approvals expire after an hour")).
+				Build()
+			if err != nil {
+				response.ReportError(err)
+				return
+			}
+
+			response.Reply("Deployment of "+service, slacker.WithBlocks(layout))
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := bot.Listen(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
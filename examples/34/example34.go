@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/sdslabs/slacker"
+)
+
+func main() {
+	templates := slacker.NewTemplates()
+
+	// Messages of the bot, in English by default and in French for users
+	// whose Slack locale is French
+	must(templates.Add(slacker.DefaultLocale, "greeting", "Hello <@{{.User}}>, you have {{.Count}} open tickets"))
+	must(templates.Add("fr", "greeting", "Bonjour <@{{.User}}>, vous avez {{.Count}} tickets ouverts"))
+	must(templates.AddBlocks(slacker.DefaultLocale, "greeting", `[
+		{"type": "section", "text": {"type": "mrkdwn", "text": {{json (printf "Hello <@%s>!" .User)}}}},
+		{"type": "context", "elements": [{"type": "mrkdwn", "text": {{json (printf "%d open tickets" .Count)}}}]}
+	]`))
+
+	// Built-in messages can be overridden too
+	must(templates.Add(slacker.DefaultLocale, slacker.TemplateError, ":warning: {{.Error}}"))
+	must(templates.Add("fr", slacker.TemplateUnauthorized, "vous n'êtes pas autorisé à exécuter cette commande"))
+
+	bot := slacker.NewClient(os.Getenv("SLACK_BOT_TOKEN"), os.Getenv("SLACK_APP_TOKEN"), slacker.WithTemplates(templates))
+
+	bot.Command("hello", &slacker.CommandDefinition{
+		Description: "Say hello",
+		Handler: func(botCtx slacker.BotContext, request slacker.Request, response slacker.ResponseWriter) {
+			data := map[string]interface{}{"User": botCtx.Event().User, "Count": 3}
+			if err := slacker.ReplyTemplate(response, "greeting", data); err != nil {
+				response.ReportError(err)
+			}
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := bot.Listen(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func must(err error) {
+	if err != nil {
+		log.Fatal(err)
+	}
+}
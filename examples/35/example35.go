@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/sdslabs/slacker"
+	"github.com/sdslabs/slacker/blocks"
+	"github.com/slack-go/slack"
+)
+
+func main() {
+	bot := slacker.NewClient(os.Getenv("SLACK_BOT_TOKEN"), os.Getenv("SLACK_APP_TOKEN"))
+
+	bot.Command("poll", &slacker.CommandDefinition{
+		Description: "Start a poll",
+		Handler: func(botCtx slacker.BotContext, request slacker.Request, response slacker.ResponseWriter) {
+			response.Reply("Lunch?", slacker.WithBlocks(blocks.New().
+				Section(blocks.Markdown("*Where should we go for lunch?*")).
+				Actions("poll",
+					blocks.Button("vote-pizza", "Pizza", "pizza"),
+					blocks.Button("vote-sushi", "Sushi", "sushi"),
+					blocks.Select("vote-other", "Something else",
+						blocks.Option("Tacos", "tacos"),
+						blocks.Option("Salad", "salad"),
+					),
+				).
+				MustBuild()))
+		},
+	})
+
+	// Every action whose action ID starts with "vote-"
+	bot.Interaction(&slacker.InteractionDefinition{
+		Description:    "Record a vote",
+		ActionIDPrefix: "vote-",
+		Handler: func(botCtx slacker.BotContext, interaction *slacker.Interaction, response slacker.ResponseWriter) {
+			response.Reply("<@" + botCtx.Event().User + "> voted for " + interaction.Value)
+		},
+	})
+
+	// Action IDs can also be matched with a regular expression
+	bot.Interaction(&slacker.InteractionDefinition{
+		Type:            slack.InteractionTypeBlockActions,
+		ActionIDPattern: `approve-\d+`,
+		Handler: func(botCtx slacker.BotContext, interaction *slacker.Interaction, response slacker.ResponseWriter) {
+			request := strings.TrimPrefix(interaction.ActionID, "approve-")
+			response.Reply("Request " + request + " approved")
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := bot.Listen(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/sdslabs/slacker"
+	"github.com/slack-go/slack"
+)
+
+// Register the slash command `/vacation` in your Slack app for this example
+func main() {
+	bot := slacker.NewClient(os.Getenv("SLACK_BOT_TOKEN"), os.Getenv("SLACK_APP_TOKEN"))
+
+	bot.Command("vacation", &slacker.CommandDefinition{
+		Description: "Request time off",
+		Handler: func(botCtx slacker.BotContext, request slacker.Request, response slacker.ResponseWriter) {
+			_, err := slacker.OpenModal(response, vacationModal())
+			if err == slacker.ErrNoTriggerID {
+				response.Reply("Please use the `/vacation` slash command")
+				return
+			}
+			if err != nil {
+				response.ReportError(err)
+			}
+		},
+	})
+
+	bot.Modal("vacation", &slacker.ModalDefinition{
+		Description: "Time off request",
+		Submit: func(botCtx slacker.BotContext, submission *slacker.ViewSubmission, response slacker.ResponseWriter) error {
+			days := submission.IntegerValue("days", "days_input", 0)
+			if days <= 0 || days > 30 {
+				return slacker.FieldErrors{"days": "Enter between 1 and 30 days"}
+			}
+
+			start := submission.Value("start", "start_input")
+			fmt.Printf("%s requested %d days off from %s\n", botCtx.Event().UserName, days, start)
+			return nil
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := bot.Listen(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func vacationModal() slack.ModalViewRequest {
+	start := slack.NewInputBlock("start",
+		slack.NewTextBlockObject(slack.PlainTextType, "First day", false, false), nil,
+		slack.NewDatePickerBlockElement("start_input"))
+	days := slack.NewInputBlock("days",
+		slack.NewTextBlockObject(slack.PlainTextType, "Number of days", false, false), nil,
+		slack.NewPlainTextInputBlockElement(nil, "days_input"))
+
+	return slack.ModalViewRequest{
+		Type:       slack.VTModal,
+		CallbackID: "vacation",
+		Title:      slack.NewTextBlockObject(slack.PlainTextType, "Time off", false, false),
+		Submit:     slack.NewTextBlockObject(slack.PlainTextType, "Request", false, false),
+		Close:      slack.NewTextBlockObject(slack.PlainTextType, "Cancel", false, false),
+		Blocks:     slack.Blocks{BlockSet: []slack.Block{start, days}},
+	}
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/sdslabs/slacker"
+	"github.com/sdslabs/slacker/blocks"
+)
+
+func main() {
+	bot := slacker.NewClient(os.Getenv("SLACK_BOT_TOKEN"), os.Getenv("SLACK_APP_TOKEN"))
+
+	bot.Command("mood", &slacker.CommandDefinition{
+		Description: "Ask for your mood",
+		BlockID:     "mood-block",
+		Handler: func(botCtx slacker.BotContext, request slacker.Request, response slacker.ResponseWriter) {
+			response.Reply("What is your mood today?", slacker.WithBlocks(blocks.New().
+				Section(blocks.Plain("What is your mood today?")).
+				Actions("mood-block",
+					blocks.Button("happy", "Happy 🙂", "happy").Primary(),
+					blocks.Button("sad", "Sad ☹️", "sad").Danger(),
+				).
+				MustBuild()))
+		},
+		// Interactions with the blocks of the command are acknowledged
+		// automatically once the handler returns
+		Interactive: func(botCtx slacker.BotContext, request slacker.Request, response slacker.ResponseWriter) {
+			switch request.Param(slacker.InteractionValue) {
+			case "happy":
+				response.Reply("I'm happy to hear you are happy!", slacker.WithReplaceOriginal(true))
+			case "sad":
+				response.Reply("I'm sorry to hear you are sad.", slacker.WithReplaceOriginal(true))
+				response.Reply("Here is a hug 🤗", slacker.WithThreadReply(true))
+			}
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := bot.Listen(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/sdslabs/slacker"
+)
+
+func main() {
+	bot := slacker.NewClient(os.Getenv("SLACK_BOT_TOKEN"), os.Getenv("SLACK_APP_TOKEN"))
+
+	bot.Shortcut("report_bug", &slacker.ShortcutDefinition{
+		Name:        "Report a bug",
+		Description: "File a bug report",
+		Handler: func(botCtx slacker.BotContext, shortcut *slacker.Shortcut, response slacker.ResponseWriter) {
+			slacker.ReplyDM(response, "Thanks! Please describe the bug in this conversation.")
+		},
+	})
+
+	bot.MessageShortcut("file_ticket", &slacker.ShortcutDefinition{
+		Name:        "File a ticket",
+		Description: "Create a ticket from this message",
+		Handler: func(botCtx slacker.BotContext, shortcut *slacker.Shortcut, response slacker.ResponseWriter) {
+			text := fmt.Sprintf("Filed a ticket for <@%s>'s message: %s", shortcut.Message.User, shortcut.Message.Text)
+			response.Reply(text, slacker.WithThreadReply(true))
+		},
+	})
+
+	// Print the app manifest for these shortcuts and the built-in features
+	if len(os.Args) > 1 && os.Args[1] == "manifest" {
+		manifest, err := bot.Manifest("Ticket Bot")
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println(string(manifest))
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := bot.Listen(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/sdslabs/slacker"
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/slackevents"
+)
+
+func main() {
+	bot := slacker.NewClient(os.Getenv("SLACK_BOT_TOKEN"), os.Getenv("SLACK_APP_TOKEN"), slacker.WithBuiltinHome(true))
+
+	bot.Command("ping", &slacker.CommandDefinition{
+		Description: "Ping!",
+		Handler: func(botCtx slacker.BotContext, request slacker.Request, response slacker.ResponseWriter) {
+			response.Reply("pong")
+		},
+	})
+
+	// Remove this handler to publish the built-in home listing the commands
+	// and the recent commands of the user
+	bot.AppHomeOpened(func(botCtx slacker.BotContext, event *slackevents.AppHomeOpenedEvent) {
+		if event.Tab != "home" {
+			return
+		}
+
+		text := slack.NewTextBlockObject(slack.MarkdownType, "Welcome <@"+event.User+">! Try `ping`.", false, false)
+		view := slack.HomeTabViewRequest{
+			Blocks: slack.Blocks{BlockSet: []slack.Block{slack.NewSectionBlock(text, nil, nil)}},
+		}
+		if err := bot.PublishHome(event.User, view); err != nil {
+			log.Println(err)
+		}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := bot.Listen(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
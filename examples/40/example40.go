@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/sdslabs/slacker"
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/slackevents"
+)
+
+func main() {
+	bot := slacker.NewClient(os.Getenv("SLACK_BOT_TOKEN"), os.Getenv("SLACK_APP_TOKEN"))
+
+	bot.OnMemberJoined(func(botCtx slacker.BotContext, event *slackevents.MemberJoinedChannelEvent) {
+		_, _, err := botCtx.Client().PostMessage(event.Channel, slack.MsgOptionText(fmt.Sprintf("Welcome <@%s>!", event.User), false))
+		if err != nil {
+			log.Println(err)
+		}
+	})
+
+	bot.OnReactionAdded(func(botCtx slacker.BotContext, event *slackevents.ReactionAddedEvent) {
+		fmt.Printf("%s reacted with :%s:\n", event.User, event.Reaction)
+	})
+
+	// Several handlers can be registered for the same event type
+	bot.OnEvent(slacker.EventReactionAdded, func(botCtx slacker.BotContext, event interface{}) {
+		fmt.Printf("Received %s in %s\n", botCtx.Event().Type, botCtx.Event().Channel)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := bot.Listen(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
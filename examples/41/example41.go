@@ -0,0 +1,41 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/sdslabs/slacker"
+)
+
+func main() {
+	bot := slacker.NewClient(os.Getenv("SLACK_BOT_TOKEN"), os.Getenv("SLACK_APP_TOKEN"))
+
+	bot.ReactionCommand("ticket", &slacker.CommandDefinition{
+		Description: "File a ticket from the message",
+		Handler: func(botCtx slacker.BotContext, request slacker.Request, response slacker.ResponseWriter) {
+			reaction := botCtx.Event().Data.(*slacker.Reaction)
+			text := fmt.Sprintf("<@%s> filed a ticket for <@%s>'s message: %s", reaction.User, reaction.Message.User, reaction.Permalink)
+			response.Reply(text)
+		},
+	})
+
+	bot.ReactionCommand("pushpin", &slacker.CommandDefinition{
+		Description: "Archive the message",
+		AuthorizationFunc: func(botCtx slacker.BotContext, request slacker.Request) bool {
+			return botCtx.Event().User == os.Getenv("ARCHIVIST_ID")
+		},
+		Handler: func(botCtx slacker.BotContext, request slacker.Request, response slacker.ResponseWriter) {
+			response.Reply("Archived: " + botCtx.Event().Text)
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := bot.Listen(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
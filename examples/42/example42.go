@@ -0,0 +1,37 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/sdslabs/slacker"
+)
+
+func main() {
+	// Fixing a typo in "deploy sevrice" by editing the message runs the command
+	// again and updates the previous reply, deleting the message deletes the
+	// reply
+	bot := slacker.NewClient(os.Getenv("SLACK_BOT_TOKEN"), os.Getenv("SLACK_APP_TOKEN"), slacker.WithEditTracking(true))
+
+	bot.Command("deploy <service>", &slacker.CommandDefinition{
+		Description: "Deploy a service",
+		Examples:    []string{"deploy api"},
+		Handler: func(botCtx slacker.BotContext, request slacker.Request, response slacker.ResponseWriter) {
+			service := request.Param("service")
+			if service != "api" && service != "web" {
+				response.Reply("Unknown service " + service)
+				return
+			}
+			response.Reply("Deploying " + service)
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := bot.Listen(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/sdslabs/slacker"
+	"github.com/sdslabs/slacker/blocks"
+	"github.com/slack-go/slack"
+)
+
+func main() {
+	bot := slacker.NewClient(os.Getenv("SLACK_BOT_TOKEN"), os.Getenv("SLACK_APP_TOKEN"))
+
+	// Previews links such as https://tickets.example.com/browse/1234
+	bot.Unfurl(`https://tickets\.example\.com/browse/(\d+)`, func(botCtx slacker.BotContext, links []*slacker.Link) (map[string]*slacker.Unfurl, error) {
+		unfurls := make(map[string]*slacker.Unfurl)
+		for _, link := range links {
+			previews, err := blocks.New().
+				Section(blocks.Markdown(fmt.Sprintf("*Ticket %s*\nStatus: open", link.Match[1]))).
+				Build()
+			if err != nil {
+				return nil, err
+			}
+			unfurls[link.URL] = &slacker.Unfurl{Blocks: previews}
+		}
+		return unfurls, nil
+	})
+
+	bot.Unfurl("dashboards.example.com", func(botCtx slacker.BotContext, links []*slacker.Link) (map[string]*slacker.Unfurl, error) {
+		unfurls := make(map[string]*slacker.Unfurl)
+		for _, link := range links {
+			unfurls[link.URL] = &slacker.Unfurl{Attachment: &slack.Attachment{Title: "Dashboard", TitleLink: link.URL}}
+		}
+		return unfurls, nil
+	})
+
+	// Print the app manifest with the unfurl domains and scopes
+	if len(os.Args) > 1 && os.Args[1] == "manifest" {
+		manifest, err := bot.Manifest("Link Bot")
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println(string(manifest))
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := bot.Listen(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,96 @@
+package slacker
+
+import (
+	"context"
+	"sync"
+)
+
+// execution is the state of a command being executed, shared by its bot
+// context and response writer
+type execution struct {
+	command BotCommand
+
+	mutex    sync.Mutex
+	failed   bool
+	progress []*ProgressTracker
+}
+
+type executionContextKey struct{}
+
+// withExecution stores the execution of the command in the context handed to
+// its handler. The command is nil for interaction handlers.
+func withExecution(ctx context.Context, cmd BotCommand) context.Context {
+	return context.WithValue(ctx, executionContextKey{}, &execution{command: cmd})
+}
+
+// executionFromContext returns the execution of the handler being run, nil if
+// the event did not match a command or interaction
+func executionFromContext(ctx context.Context) *execution {
+	if ctx == nil {
+		return nil
+	}
+	exec, _ := ctx.Value(executionContextKey{}).(*execution)
+	return exec
+}
+
+// commandFromContext returns the command being executed, nil if the event did
+// not match a command
+func commandFromContext(ctx context.Context) BotCommand {
+	if exec := executionFromContext(ctx); exec != nil {
+		return exec.command
+	}
+	return nil
+}
+
+// fail marks the command as failed
+func (e *execution) fail() {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.failed = true
+}
+
+// hasFailed indicates if the command reported an error
+func (e *execution) hasFailed() bool {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	return e.failed
+}
+
+// track registers a progress tracker to finish once the handler returns
+func (e *execution) track(tracker *ProgressTracker) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.progress = append(e.progress, tracker)
+}
+
+// finish finishes the progress trackers the handler left running
+func (e *execution) finish(failed bool) {
+	e.mutex.Lock()
+	trackers := e.progress
+	e.mutex.Unlock()
+
+	for _, tracker := range trackers {
+		tracker.finish(failed, empty)
+	}
+}
+
+// run runs the handler and finishes the progress trackers it left running,
+// also when it panics. It reports whether the handler failed.
+func (e *execution) run(handler func()) (failed bool) {
+	if e == nil {
+		handler()
+		return false
+	}
+
+	defer func() {
+		recovered := recover()
+		failed = recovered != nil || e.hasFailed()
+		e.finish(failed)
+		if recovered != nil {
+			panic(recovered)
+		}
+	}()
+
+	handler()
+	return false
+}
@@ -0,0 +1,149 @@
+package slacker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/slackevents"
+)
+
+const (
+	appHomeOpenedType   = "app_home_opened"
+	homeTab             = "home"
+	homeHistoryKey      = "home/history"
+	homeHistoryLength   = 10
+	homeMaxCommands     = 80
+	homeHistoryFormat   = "`%s` - %s"
+	homeTimestampFormat = "<!date^%d^{date_short_pretty} at {time}|%s>"
+)
+
+// AppHomeOpened handles users opening the tabs of the app's home. It replaces
+// the built-in home enabled with WithBuiltinHome.
+func (s *Slacker) AppHomeOpened(handler func(botCtx BotContext, event *slackevents.AppHomeOpenedEvent)) {
+	s.appHomeOpenedHandler = handler
+}
+
+// PublishHome publishes the view as the home tab of the user
+func (s *Slacker) PublishHome(userID string, view slack.HomeTabViewRequest) error {
+	if len(view.Type) == 0 {
+		view.Type = slack.VTHomeTab
+	}
+	_, err := s.client.PublishView(userID, view, empty)
+	return err
+}
+
+// homeEnabled indicates if the app home tab is handled
+func (s *Slacker) homeEnabled() bool {
+	return s.appHomeOpenedHandler != nil || s.builtinHome
+}
+
+func (s *Slacker) handleAppHomeOpened(ctx context.Context, event *slackevents.AppHomeOpenedEvent) {
+	ev := &MessageEvent{
+		Channel: event.Channel,
+		User:    event.User,
+		Data:    event,
+		Type:    appHomeOpenedType,
+	}
+	botCtx := s.botContextConstructor(withSlacker(ctx, s), s.client, s.socketModeClient, ev)
+
+	if s.appHomeOpenedHandler != nil {
+		s.appHomeOpenedHandler(botCtx, event)
+		return
+	}
+
+	if !s.builtinHome || event.Tab != homeTab {
+		return
+	}
+	if err := s.PublishHome(event.User, s.builtinHomeView(botCtx)); err != nil {
+		s.reportError(fmt.Errorf("unable to publish home of %s: %v", event.User, err))
+	}
+}
+
+// builtinHomeView lists the commands and the recent commands of the user
+func (s *Slacker) builtinHomeView(botCtx BotContext) slack.HomeTabViewRequest {
+	var blocks []slack.Block
+	blocks = append(blocks, slack.NewHeaderBlock(plainText(builtinText(botCtx, TemplateHomeCommands, nil))))
+
+	count := 0
+	for _, command := range s.botCommands {
+		if command.Definition().HideHelp || count == homeMaxCommands {
+			continue
+		}
+		count++
+
+		text := fmt.Sprintf(codeMessageFormat, command.Usage())
+		if len(command.Definition().Description) > 0 {
+			text += newLine + command.Definition().Description
+		}
+		blocks = append(blocks, slack.NewSectionBlock(markdownText(text), nil, nil))
+	}
+
+	blocks = append(blocks, slack.NewDividerBlock())
+	blocks = append(blocks, slack.NewHeaderBlock(plainText(builtinText(botCtx, TemplateHomeHistory, nil))))
+
+	history := s.commandHistory(botCtx.Event())
+	if len(history) == 0 {
+		text := builtinText(botCtx, TemplateHomeNoHistory, nil)
+		blocks = append(blocks, slack.NewContextBlock(empty, markdownText(text)))
+	} else {
+		var lines []string
+		for _, entry := range history {
+			when := fmt.Sprintf(homeTimestampFormat, entry.Time.Unix(), entry.Time.UTC().Format(time.RFC1123))
+			lines = append(lines, fmt.Sprintf(homeHistoryFormat, entry.Text, when))
+		}
+		blocks = append(blocks, slack.NewSectionBlock(markdownText(strings.Join(lines, newLine)), nil, nil))
+	}
+
+	return slack.HomeTabViewRequest{Type: slack.VTHomeTab, Blocks: slack.Blocks{BlockSet: blocks}}
+}
+
+type historyEntry struct {
+	Text string    `json:"text"`
+	Time time.Time `json:"time"`
+}
+
+// commandHistory returns the most recent commands of the user, latest first
+func (s *Slacker) commandHistory(ev *MessageEvent) []historyEntry {
+	value, found, err := s.scopedStore(StoreScopeUser, ev).Get(homeHistoryKey)
+	if err != nil || !found {
+		return nil
+	}
+
+	var history []historyEntry
+	if err := json.Unmarshal([]byte(value), &history); err != nil {
+		return nil
+	}
+	return history
+}
+
+// recordHistory remembers the command the user ran, for the built-in home
+func (s *Slacker) recordHistory(ev *MessageEvent) {
+	if !s.builtinHome || len(ev.User) == 0 {
+		return
+	}
+
+	history := append([]historyEntry{{Text: ev.Text, Time: time.Now()}}, s.commandHistory(ev)...)
+	if len(history) > homeHistoryLength {
+		history = history[:homeHistoryLength]
+	}
+
+	data, err := json.Marshal(history)
+	if err != nil {
+		return
+	}
+	if err := s.scopedStore(StoreScopeUser, ev).Set(homeHistoryKey, string(data), 0); err != nil {
+		fmt.Printf("unable to record command history: %v\n", err)
+	}
+}
+
+func plainText(text string) *slack.TextBlockObject {
+	return slack.NewTextBlockObject(slack.PlainTextType, text, true, false)
+}
+
+func markdownText(text string) *slack.TextBlockObject {
+	return slack.NewTextBlockObject(slack.MarkdownType, text, false, false)
+}
@@ -0,0 +1,334 @@
+package slacker
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	allot "github.com/sdslabs/allot/pkg"
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/socketmode"
+)
+
+// InteractionDefinition structure contains definition of an interaction
+// handler. An interaction is handled by the first definition whose set fields
+// all match it.
+type InteractionDefinition struct {
+	Description string
+
+	// Type restricts the definition to an interaction type, eg.
+	// slack.InteractionTypeViewSubmission
+	Type slack.InteractionType
+
+	// ActionID matches block actions with exactly this action_id
+	ActionID string
+
+	// ActionIDPrefix matches block actions whose action_id starts with it,
+	// eg. "vote-" for "vote-yes" and "vote-no"
+	ActionIDPrefix string
+
+	// ActionIDPattern matches block actions whose action_id fully matches the
+	// regular expression
+	ActionIDPattern string
+
+	// BlockID matches block actions from the block with this block_id
+	BlockID string
+
+	// CallbackID matches views, shortcuts and message actions with this
+	// callback_id
+	CallbackID string
+
+	// Handler handles the interaction. Views and global shortcuts have no
+	// channel, replies to them are sent to the user by direct message.
+	Handler func(botCtx BotContext, interaction *Interaction, response ResponseWriter)
+}
+
+// Interaction contains the parsed payload of an interactive event
+type Interaction struct {
+	Type       slack.InteractionType
+	ActionID   string
+	BlockID    string
+	CallbackID string
+
+	// Value is the value of the action: the value of a button, the selected
+	// option, user, channel, conversation, date or time
+	Value string
+
+	// Values are the selected values of multi-select menus
+	Values []string
+
+	// Action is the matched action of block actions, nil otherwise
+	Action *slack.BlockAction
+
+	Callback *slack.InteractionCallback
+	Event    *socketmode.Event
+}
+
+// Request parameters of interactive command handlers
+const (
+	InteractionActionID   = "action_id"
+	InteractionBlockID    = "block_id"
+	InteractionCallbackID = "callback_id"
+	InteractionValue      = "value"
+)
+
+// interactionMatch exposes the interaction as the parameters of a request
+type interactionMatch struct {
+	interaction *Interaction
+}
+
+// String returns the interaction parameter
+func (m *interactionMatch) String(name string) (string, error) {
+	switch name {
+	case InteractionActionID:
+		return m.interaction.ActionID, nil
+	case InteractionBlockID:
+		return m.interaction.BlockID, nil
+	case InteractionCallbackID:
+		return m.interaction.CallbackID, nil
+	case InteractionValue:
+		return m.interaction.Value, nil
+	}
+	return empty, fmt.Errorf("unknown interaction parameter %q", name)
+}
+
+// Integer returns the interaction parameter as an integer
+func (m *interactionMatch) Integer(name string) (int, error) {
+	value, err := m.String(name)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(value)
+}
+
+// RemainingString is not supported by interactions
+func (m *interactionMatch) RemainingString(text string) (string, error) {
+	return empty, fmt.Errorf("interactions have no remaining string")
+}
+
+// Match is not supported by interactions
+func (m *interactionMatch) Match(position int) (string, error) {
+	return empty, fmt.Errorf("interactions have no positional parameters")
+}
+
+// Parameter returns the interaction parameter named like the parameter
+func (m *interactionMatch) Parameter(param allot.ParameterInterface) (string, error) {
+	return m.String(param.Name())
+}
+
+type interactionRoute struct {
+	definition *InteractionDefinition
+	pattern    *regexp.Regexp
+	err        error
+}
+
+// Interaction registers a handler for the block actions, view submissions,
+// closed views, shortcuts and message actions matching the definition.
+// Interactions handled this way are acknowledged automatically.
+func (s *Slacker) Interaction(definition *InteractionDefinition) {
+	route := &interactionRoute{definition: definition}
+	if len(definition.ActionIDPattern) > 0 {
+		route.pattern, route.err = regexp.Compile("^(?:" + definition.ActionIDPattern + ")$")
+	}
+	s.interactions = append(s.interactions, route)
+}
+
+// validate records the problems of the definition
+func (r *interactionRoute) validate(result *ValidationResult) {
+	d := r.definition
+	name := r.name()
+	if r.err != nil {
+		result.errorf("interaction %s has an invalid action ID pattern: %v", name, r.err)
+	}
+	if d.Handler == nil {
+		result.errorf("interaction %s has no handler", name)
+	}
+	if len(d.Type) == 0 && len(d.ActionID) == 0 && len(d.ActionIDPrefix) == 0 && len(d.ActionIDPattern) == 0 &&
+		len(d.BlockID) == 0 && len(d.CallbackID) == 0 {
+		result.warnf("interaction %s matches every interaction", name)
+	}
+}
+
+// name describes the definition in validation messages
+func (r *interactionRoute) name() string {
+	d := r.definition
+	for _, candidate := range []string{d.ActionID, d.ActionIDPrefix, d.ActionIDPattern, d.BlockID, d.CallbackID, string(d.Type)} {
+		if len(candidate) > 0 {
+			return fmt.Sprintf("%q", candidate)
+		}
+	}
+	return fmt.Sprintf("%q", d.Description)
+}
+
+// matches checks if the interaction is handled by the definition
+func (r *interactionRoute) matches(interaction *Interaction) bool {
+	d := r.definition
+	if r.err != nil || d.Handler == nil {
+		return false
+	}
+	if len(d.Type) > 0 && d.Type != interaction.Type {
+		return false
+	}
+
+	hasActionCriteria := len(d.ActionID) > 0 || len(d.ActionIDPrefix) > 0 || r.pattern != nil || len(d.BlockID) > 0
+	if hasActionCriteria && interaction.Action == nil {
+		return false
+	}
+	if len(d.ActionID) > 0 && d.ActionID != interaction.ActionID {
+		return false
+	}
+	if len(d.ActionIDPrefix) > 0 && !strings.HasPrefix(interaction.ActionID, d.ActionIDPrefix) {
+		return false
+	}
+	if r.pattern != nil && !r.pattern.MatchString(interaction.ActionID) {
+		return false
+	}
+	if len(d.BlockID) > 0 && d.BlockID != interaction.BlockID {
+		return false
+	}
+	if len(d.CallbackID) > 0 && d.CallbackID != interaction.CallbackID {
+		return false
+	}
+	return true
+}
+
+// newInteractions parses the callback, one interaction per block action
+func newInteractions(evt *socketmode.Event, callback *slack.InteractionCallback) []*Interaction {
+	callbackID := callback.CallbackID
+	if callback.Type == slack.InteractionTypeViewSubmission || callback.Type == slack.InteractionTypeViewClosed {
+		callbackID = callback.View.CallbackID
+	}
+
+	if callback.Type != slack.InteractionTypeBlockActions {
+		return []*Interaction{{
+			Type:       callback.Type,
+			CallbackID: callbackID,
+			Callback:   callback,
+			Event:      evt,
+		}}
+	}
+
+	var interactions []*Interaction
+	for _, action := range callback.ActionCallback.BlockActions {
+		interactions = append(interactions, newInteraction(evt, callback, action))
+	}
+	return interactions
+}
+
+// newInteraction parses a block action of the callback
+func newInteraction(evt *socketmode.Event, callback *slack.InteractionCallback, action *slack.BlockAction) *Interaction {
+	value, values := actionValues(action)
+	return &Interaction{
+		Type:       callback.Type,
+		ActionID:   action.ActionID,
+		BlockID:    action.BlockID,
+		CallbackID: callback.CallbackID,
+		Value:      value,
+		Values:     values,
+		Action:     action,
+		Callback:   callback,
+		Event:      evt,
+	}
+}
+
+// actionValues returns the value of the action, whichever kind of element it
+// comes from
+func actionValues(action *slack.BlockAction) (string, []string) {
+	var values []string
+	for _, option := range action.SelectedOptions {
+		values = append(values, option.Value)
+	}
+	values = append(values, action.SelectedUsers...)
+	values = append(values, action.SelectedChannels...)
+	values = append(values, action.SelectedConversations...)
+
+	for _, value := range []string{
+		action.Value,
+		action.SelectedOption.Value,
+		action.SelectedUser,
+		action.SelectedChannel,
+		action.SelectedConversation,
+		action.SelectedDate,
+		action.SelectedTime,
+	} {
+		if len(value) > 0 {
+			return value, values
+		}
+	}
+	if len(values) > 0 {
+		return values[0], values
+	}
+	return empty, values
+}
+
+// newInteractionMessageEvent describes the interaction as an event, for the
+// bot context and response writer of its handler
+func newInteractionMessageEvent(callback *slack.InteractionCallback) *MessageEvent {
+	channel := callback.Channel.ID
+	if len(channel) == 0 {
+		channel = callback.Container.ChannelID
+	}
+
+	timestamp := callback.Container.MessageTs
+	if len(timestamp) == 0 {
+		timestamp = callback.Message.Timestamp
+	}
+	if len(timestamp) == 0 {
+		timestamp = callback.MessageTs
+	}
+
+	threadTimestamp := callback.Container.ThreadTs
+	if len(threadTimestamp) == 0 {
+		threadTimestamp = callback.Message.ThreadTimestamp
+	}
+
+	return &MessageEvent{
+		Channel:         channel,
+		ChannelName:     callback.Channel.Name,
+		User:            callback.User.ID,
+		UserName:        callback.User.Name,
+		Text:            callback.Message.Text,
+		Data:            callback,
+		Type:            string(callback.Type),
+		TimeStamp:       timestamp,
+		ThreadTimeStamp: threadTimestamp,
+	}
+}
+
+// routeInteraction runs the handlers of the interactions and acknowledges
+// them, reporting whether any matched
+func (s *Slacker) routeInteraction(ctx context.Context, evt *socketmode.Event, callback *slack.InteractionCallback, req *socketmode.Request) bool {
+	type match struct {
+		route       *interactionRoute
+		interaction *Interaction
+	}
+
+	var matches []match
+	for _, interaction := range newInteractions(evt, callback) {
+		for _, route := range s.interactions {
+			if route.matches(interaction) {
+				matches = append(matches, match{route: route, interaction: interaction})
+				break
+			}
+		}
+	}
+	if len(matches) == 0 {
+		return false
+	}
+
+	ack := newAcknowledger(s.socketModeClient, req)
+	defer ack.ack(nil)
+
+	ctx = withAcknowledger(withSlacker(ctx, s), ack)
+	for _, m := range matches {
+		handlerCtx := withExecution(ctx, nil)
+		botCtx := s.botContextConstructor(handlerCtx, s.client, s.socketModeClient, newInteractionMessageEvent(callback))
+		response := s.responseConstructor(botCtx)
+		executionFromContext(handlerCtx).run(func() {
+			m.route.definition.Handler(botCtx, m.interaction, response)
+		})
+	}
+	return true
+}
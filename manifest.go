@@ -0,0 +1,187 @@
+package slacker
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+const (
+	manifestShortcutGlobal  = "global"
+	manifestShortcutMessage = "message"
+)
+
+var (
+	manifestBotScopes = []string{
+		"app_mentions:read",
+		"channels:history",
+		"channels:read",
+		"chat:write",
+		"files:write",
+		"groups:history",
+		"groups:read",
+		"im:history",
+		"im:read",
+		"im:write",
+		"mpim:history",
+		"mpim:read",
+		"reactions:write",
+		"users:read",
+	}
+
+	manifestBotEvents = []string{
+		"app_mention",
+		"message.channels",
+		"message.groups",
+		"message.im",
+		"message.mpim",
+	}
+)
+
+type manifest struct {
+	DisplayInformation manifestDisplayInformation `json:"display_information"`
+	Features           manifestFeatures           `json:"features"`
+	OAuthConfig        manifestOAuthConfig        `json:"oauth_config"`
+	Settings           manifestSettings           `json:"settings"`
+}
+
+type manifestDisplayInformation struct {
+	Name string `json:"name"`
+}
+
+type manifestFeatures struct {
+	AppHome       manifestAppHome    `json:"app_home"`
+	BotUser       manifestBotUser    `json:"bot_user"`
+	Shortcuts     []manifestShortcut `json:"shortcuts,omitempty"`
+	UnfurlDomains []string           `json:"unfurl_domains,omitempty"`
+}
+
+type manifestAppHome struct {
+	HomeTabEnabled             bool `json:"home_tab_enabled"`
+	MessagesTabEnabled         bool `json:"messages_tab_enabled"`
+	MessagesTabReadOnlyEnabled bool `json:"messages_tab_read_only_enabled"`
+}
+
+type manifestBotUser struct {
+	DisplayName  string `json:"display_name"`
+	AlwaysOnline bool   `json:"always_online"`
+}
+
+type manifestShortcut struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	CallbackID  string `json:"callback_id"`
+	Description string `json:"description"`
+}
+
+type manifestOAuthConfig struct {
+	Scopes manifestScopes `json:"scopes"`
+}
+
+type manifestScopes struct {
+	Bot []string `json:"bot"`
+}
+
+type manifestSettings struct {
+	EventSubscriptions manifestEventSubscriptions `json:"event_subscriptions"`
+	Interactivity      manifestInteractivity      `json:"interactivity"`
+	OrgDeployEnabled   bool                       `json:"org_deploy_enabled"`
+	SocketModeEnabled  bool                       `json:"socket_mode_enabled"`
+}
+
+type manifestEventSubscriptions struct {
+	BotEvents []string `json:"bot_events"`
+}
+
+type manifestInteractivity struct {
+	IsEnabled bool `json:"is_enabled"`
+}
+
+// Manifest generates a Slack app manifest, in JSON, with the scopes, events,
+// shortcuts and settings needed by the registered handlers
+func (s *Slacker) Manifest(appName string) ([]byte, error) {
+	m := &manifest{
+		DisplayInformation: manifestDisplayInformation{Name: appName},
+		Features: manifestFeatures{
+			AppHome: manifestAppHome{
+				MessagesTabEnabled: true,
+			},
+			BotUser: manifestBotUser{DisplayName: appName},
+		},
+		Settings: manifestSettings{
+			Interactivity:     manifestInteractivity{IsEnabled: true},
+			SocketModeEnabled: true,
+		},
+	}
+
+	scopes := newStringSet(manifestBotScopes...)
+	events := newStringSet(manifestBotEvents...)
+
+	for _, shortcut := range s.shortcuts {
+		kind := manifestShortcutGlobal
+		if shortcut.message {
+			kind = manifestShortcutMessage
+		}
+		m.Features.Shortcuts = append(m.Features.Shortcuts, manifestShortcut{
+			Name:        shortcut.definition.Name,
+			Type:        kind,
+			CallbackID:  shortcut.callbackID,
+			Description: shortcut.definition.Description,
+		})
+	}
+	if len(s.shortcuts) > 0 {
+		scopes.add("commands")
+	}
+
+	if len(s.reactionCommands) > 0 {
+		events.add(EventReactionAdded)
+		scopes.add("reactions:read")
+	}
+
+	for eventType := range s.eventHandlers {
+		events.add(eventType)
+		for _, scope := range eventScopes[eventType] {
+			scopes.add(scope)
+		}
+	}
+
+	if len(s.unfurls) > 0 {
+		m.Features.UnfurlDomains = s.unfurlDomains()
+		events.add(linkSharedType)
+		scopes.add("links:read")
+		scopes.add("links:write")
+	}
+
+	if s.homeEnabled() {
+		m.Features.AppHome.HomeTabEnabled = true
+		events.add(appHomeOpenedType)
+	}
+
+	m.OAuthConfig.Scopes.Bot = scopes.sorted()
+	m.Settings.EventSubscriptions.BotEvents = events.sorted()
+	return json.MarshalIndent(m, empty, "  ")
+}
+
+type stringSet map[string]bool
+
+func newStringSet(values ...string) stringSet {
+	set := make(stringSet)
+	for _, value := range values {
+		set.add(value)
+	}
+	return set
+}
+
+func (s stringSet) add(values ...string) {
+	for _, value := range values {
+		s[value] = true
+	}
+}
+
+func (s stringSet) sorted() []string {
+	var values []string
+	for value := range s {
+		values = append(values, value)
+	}
+	sort.Strings(values)
+	return values
+}
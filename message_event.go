@@ -1,5 +1,7 @@
 package slacker
 
+import "github.com/slack-go/slack/socketmode"
+
 // MessageEvent contains details common to message based events, including the
 // raw event as returned from Slack along with the corresponding event type.
 // The struct should be kept minimal and only include data that is commonly
@@ -50,6 +52,11 @@ func (e *MessageEvent) IsThread() bool {
 	return true
 }
 
+// IsSlashCommand indicates if the event is a slash command
+func (e *MessageEvent) IsSlashCommand() bool {
+	return e.Type == string(socketmode.RequestTypeSlashCommands)
+}
+
 // IsBot indicates if the message was sent by a bot
 func (e *MessageEvent) IsBot() bool {
 	return e.BotID != ""
@@ -0,0 +1,114 @@
+package slacker
+
+import (
+	"errors"
+
+	"github.com/slack-go/slack"
+)
+
+// ErrMessageUnavailable is returned when an operation needs a message
+// timestamp that Slack did not provide, eg. for ephemeral messages or slash
+// command responses
+var ErrMessageUnavailable = errors.New("message cannot be referenced, it is ephemeral or was sent as a slash command response")
+
+// MessageHandle refers to a message posted by the bot so that it can later be
+// updated, deleted or followed up on
+type MessageHandle struct {
+	client      *slack.Client
+	channel     string
+	timestamp   string
+	thread      string
+	ephemeral   bool
+	responseURL string
+}
+
+// Channel returns the ID of the channel the message was posted in
+func (m *MessageHandle) Channel() string {
+	return m.channel
+}
+
+// TimeStamp returns the timestamp of the message, empty if Slack did not
+// provide one
+func (m *MessageHandle) TimeStamp() string {
+	return m.timestamp
+}
+
+// IsEphemeral indicates if the message is only visible to a single user
+func (m *MessageHandle) IsEphemeral() bool {
+	return m.ephemeral
+}
+
+// Update replaces the content of the message
+func (m *MessageHandle) Update(text string, options ...ReplyOption) error {
+	defaults := NewReplyDefaults(options...)
+	msg := &message{text: text, attachments: defaults.Attachments, blocks: defaults.Blocks}
+
+	if len(m.responseURL) > 0 {
+		opts := append(msg.options(), slack.MsgOptionReplaceOriginal(m.responseURL))
+		_, _, err := m.client.PostMessage(m.channel, opts...)
+		return err
+	}
+
+	if !m.referable() {
+		return ErrMessageUnavailable
+	}
+	_, _, _, err := m.client.UpdateMessage(m.channel, m.timestamp, msg.options()...)
+	return err
+}
+
+// Delete removes the message
+func (m *MessageHandle) Delete() error {
+	if len(m.responseURL) > 0 {
+		_, _, err := m.client.PostMessage(m.channel, slack.MsgOptionDeleteOriginal(m.responseURL))
+		return err
+	}
+
+	if !m.referable() {
+		return ErrMessageUnavailable
+	}
+	_, _, err := m.client.DeleteMessage(m.channel, m.timestamp)
+	return err
+}
+
+// ReplyInThread posts a reply in the thread of the message
+func (m *MessageHandle) ReplyInThread(text string, options ...ReplyOption) (*MessageHandle, error) {
+	if !m.referable() {
+		return nil, ErrMessageUnavailable
+	}
+
+	thread := m.thread
+	if len(thread) == 0 {
+		thread = m.timestamp
+	}
+
+	defaults := NewReplyDefaults(options...)
+	msg := &message{text: text, attachments: defaults.Attachments, blocks: defaults.Blocks}
+	opts := append(msg.options(), slack.MsgOptionTS(thread))
+
+	channel, timestamp, err := m.client.PostMessage(m.channel, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &MessageHandle{client: m.client, channel: channel, timestamp: timestamp, thread: thread}, nil
+}
+
+// AddReaction adds an emoji reaction, eg. "white_check_mark", to the message
+func (m *MessageHandle) AddReaction(emoji string) error {
+	if !m.referable() {
+		return ErrMessageUnavailable
+	}
+	return m.client.AddReaction(emoji, slack.NewRefToMessage(m.channel, m.timestamp))
+}
+
+// Permalink returns a link to the message
+func (m *MessageHandle) Permalink() (string, error) {
+	if !m.referable() {
+		return empty, ErrMessageUnavailable
+	}
+	return m.client.GetPermalink(&slack.PermalinkParameters{Channel: m.channel, Ts: m.timestamp})
+}
+
+// referable indicates if the message can be addressed through the Web API
+func (m *MessageHandle) referable() bool {
+	return !m.ephemeral && len(m.timestamp) > 0
+}
@@ -0,0 +1,266 @@
+package slacker
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/slack-go/slack"
+)
+
+// ErrNoTriggerID is returned when opening a modal from an event that cannot
+// open one, only slash commands and interactions can
+var ErrNoTriggerID = errors.New("modals can only be opened from slash commands and interactions")
+
+// ModalDefinition structure contains definition of the handlers of a modal,
+// identified by the callback ID of its view
+type ModalDefinition struct {
+	Description string
+
+	// Submit handles the submission of the modal. Returning FieldErrors keeps
+	// the modal open and shows the errors on the input blocks, other errors
+	// are reported to the error handler and close the modal. Submissions have
+	// no channel, replies are sent to the user by direct message.
+	Submit func(botCtx BotContext, submission *ViewSubmission, response ResponseWriter) error
+
+	// Close handles the modal being canceled, the view must be opened with
+	// notify_on_close set
+	Close func(botCtx BotContext, submission *ViewSubmission, response ResponseWriter)
+}
+
+// FieldErrors maps the block IDs of input blocks to the error shown below them
+type FieldErrors map[string]string
+
+// Error describes the invalid fields
+func (f FieldErrors) Error() string {
+	var fields []string
+	for blockID, err := range f {
+		fields = append(fields, fmt.Sprintf("%s: %s", blockID, err))
+	}
+	sort.Strings(fields)
+	return "invalid fields: " + strings.Join(fields, ", ")
+}
+
+// ViewSubmission contains a submitted or closed modal and the values of its
+// inputs
+type ViewSubmission struct {
+	View     *slack.View
+	Callback *slack.InteractionCallback
+}
+
+// Value returns the value of the input, looked up by action ID in every block
+// when the block ID is empty
+func (v *ViewSubmission) Value(blockID string, actionID string) string {
+	return v.StringValue(blockID, actionID, empty)
+}
+
+// StringValue returns the value of the input. If not found, return the
+// default string value
+func (v *ViewSubmission) StringValue(blockID string, actionID string, defaultValue string) string {
+	action, ok := v.action(blockID, actionID)
+	if !ok {
+		return defaultValue
+	}
+	value, _ := actionValues(&action)
+	if len(value) == 0 {
+		return defaultValue
+	}
+	return value
+}
+
+// IntegerValue returns the value of the input as an integer. If not found or
+// invalid, return the default integer value
+func (v *ViewSubmission) IntegerValue(blockID string, actionID string, defaultValue int) int {
+	value, err := strconv.Atoi(strings.TrimSpace(v.Value(blockID, actionID)))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// FloatValue returns the value of the input as a float. If not found or
+// invalid, return the default float value
+func (v *ViewSubmission) FloatValue(blockID string, actionID string, defaultValue float64) float64 {
+	value, err := strconv.ParseFloat(strings.TrimSpace(v.Value(blockID, actionID)), 64)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// BooleanValue returns the value of the input as a boolean, checkboxes are true
+// when any option is checked. If not found or invalid, return the default
+// boolean value
+func (v *ViewSubmission) BooleanValue(blockID string, actionID string, defaultValue bool) bool {
+	action, ok := v.action(blockID, actionID)
+	if !ok {
+		return defaultValue
+	}
+	if action.Type == slack.ActionType(slack.METCheckboxGroups) {
+		return len(action.SelectedOptions) > 0
+	}
+
+	value, err := strconv.ParseBool(strings.TrimSpace(v.Value(blockID, actionID)))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// Values returns the selected values of a multi-select or checkbox input
+func (v *ViewSubmission) Values(blockID string, actionID string) []string {
+	action, ok := v.action(blockID, actionID)
+	if !ok {
+		return nil
+	}
+	_, values := actionValues(&action)
+	return values
+}
+
+// PrivateMetadata returns the private metadata the view was opened with
+func (v *ViewSubmission) PrivateMetadata() string {
+	return v.View.PrivateMetadata
+}
+
+func (v *ViewSubmission) action(blockID string, actionID string) (slack.BlockAction, bool) {
+	if v.View == nil || v.View.State == nil {
+		return slack.BlockAction{}, false
+	}
+
+	if len(blockID) > 0 {
+		action, ok := v.View.State.Values[blockID][actionID]
+		return action, ok
+	}
+	for _, actions := range v.View.State.Values {
+		if action, ok := actions[actionID]; ok {
+			return action, true
+		}
+	}
+	return slack.BlockAction{}, false
+}
+
+// Modal registers the handlers of the modals whose view has the callback ID
+func (s *Slacker) Modal(callbackID string, definition *ModalDefinition) {
+	if definition.Submit != nil {
+		s.Interaction(&InteractionDefinition{
+			Description: definition.Description,
+			Type:        slack.InteractionTypeViewSubmission,
+			CallbackID:  callbackID,
+			Handler: func(botCtx BotContext, interaction *Interaction, response ResponseWriter) {
+				submission := &ViewSubmission{View: &interaction.Callback.View, Callback: interaction.Callback}
+				err := definition.Submit(botCtx, submission, response)
+				if err == nil {
+					return
+				}
+
+				var fieldErrors FieldErrors
+				if errors.As(err, &fieldErrors) {
+					if ack := acknowledgerFromContext(botCtx.Context()); ack != nil {
+						ack.ack(slack.NewErrorsViewSubmissionResponse(fieldErrors))
+					}
+					return
+				}
+				s.reportError(fmt.Errorf("modal %q failed: %v", callbackID, err))
+			},
+		})
+	}
+
+	if definition.Close != nil {
+		s.Interaction(&InteractionDefinition{
+			Description: definition.Description,
+			Type:        slack.InteractionTypeViewClosed,
+			CallbackID:  callbackID,
+			Handler: func(botCtx BotContext, interaction *Interaction, response ResponseWriter) {
+				definition.Close(botCtx, &ViewSubmission{View: &interaction.Callback.View, Callback: interaction.Callback}, response)
+			},
+		})
+	}
+}
+
+// OpenModal opens the modal for the user who ran the slash command or
+// interaction
+func OpenModal(response ResponseWriter, view slack.ModalViewRequest) (*slack.View, error) {
+	writer, ok := response.(interface {
+		OpenModal(view slack.ModalViewRequest) (*slack.View, error)
+	})
+	if !ok {
+		return nil, ErrUnsupportedResponseWriter
+	}
+	return writer.OpenModal(view)
+}
+
+// OpenModal opens the modal for the user who ran the slash command or
+// interaction
+func (r *response) OpenModal(view slack.ModalViewRequest) (*slack.View, error) {
+	triggerID := r.triggerID()
+	if len(triggerID) == 0 {
+		return nil, ErrNoTriggerID
+	}
+
+	opened, err := r.botCtx.Client().OpenViewContext(r.botCtx.Context(), triggerID, view)
+	if err != nil {
+		return nil, err
+	}
+	return &opened.View, nil
+}
+
+// PushModal opens the modal on top of the modal the interaction came from
+func PushModal(response ResponseWriter, view slack.ModalViewRequest) (*slack.View, error) {
+	writer, ok := response.(interface {
+		PushModal(view slack.ModalViewRequest) (*slack.View, error)
+	})
+	if !ok {
+		return nil, ErrUnsupportedResponseWriter
+	}
+	return writer.PushModal(view)
+}
+
+// PushModal opens the modal on top of the modal the interaction came from
+func (r *response) PushModal(view slack.ModalViewRequest) (*slack.View, error) {
+	triggerID := r.triggerID()
+	if len(triggerID) == 0 {
+		return nil, ErrNoTriggerID
+	}
+
+	pushed, err := r.botCtx.Client().PushViewContext(r.botCtx.Context(), triggerID, view)
+	if err != nil {
+		return nil, err
+	}
+	return &pushed.View, nil
+}
+
+// UpdateModal replaces the content of an open modal
+func UpdateModal(response ResponseWriter, viewID string, view slack.ModalViewRequest) (*slack.View, error) {
+	writer, ok := response.(interface {
+		UpdateModal(viewID string, view slack.ModalViewRequest) (*slack.View, error)
+	})
+	if !ok {
+		return nil, ErrUnsupportedResponseWriter
+	}
+	return writer.UpdateModal(viewID, view)
+}
+
+// UpdateModal replaces the content of an open modal
+func (r *response) UpdateModal(viewID string, view slack.ModalViewRequest) (*slack.View, error) {
+	updated, err := r.botCtx.Client().UpdateViewContext(r.botCtx.Context(), view, empty, empty, viewID)
+	if err != nil {
+		return nil, err
+	}
+	return &updated.View, nil
+}
+
+// triggerID returns the trigger ID of the slash command or interaction being
+// handled, empty for other events
+func (r *response) triggerID() string {
+	if responder := slashCommandResponderFromContext(r.botCtx.Context()); responder != nil {
+		return responder.command.TriggerID
+	}
+	if ev := r.botCtx.Event(); ev != nil {
+		if callback, ok := ev.Data.(*slack.InteractionCallback); ok {
+			return callback.TriggerID
+		}
+	}
+	return empty
+}
@@ -0,0 +1,163 @@
+package slacker
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/slack-go/slack"
+)
+
+const (
+	maxMessageLength   = 40000
+	maxMessageBlocks   = 50
+	codeFence          = "```"
+	overflowFilename   = "overflow.txt"
+	overflowFiletype   = "text"
+	codeFenceAllowance = 2 * (len(codeFence) + len(newLine))
+)
+
+// OverflowPolicy instructs Slacker on how to deliver replies exceeding the
+// size limits of a Slack message.
+type OverflowPolicy int
+
+const (
+	// OverflowPolicySplit sends oversized replies as several messages, split
+	// at line boundaries, code fences and block edges.
+	OverflowPolicySplit OverflowPolicy = iota
+
+	// OverflowPolicySnippet sends as much of the text as fits in one message
+	// and uploads the rest as a snippet. Ephemeral replies and blocks are
+	// split instead, since snippets are always visible to the channel.
+	OverflowPolicySnippet
+)
+
+// splitMessage divides the message into messages within the size limits of
+// Slack. Text accompanying blocks is only a notification fallback, so it is
+// truncated rather than split. Attachments are sent with the last message.
+func splitMessage(msg *message) []*message {
+	if len(msg.text) <= maxMessageLength && len(msg.blocks) <= maxMessageBlocks {
+		return []*message{msg}
+	}
+
+	var parts []*message
+	if len(msg.blocks) > 0 {
+		for i, blocks := range splitBlocks(msg.blocks, maxMessageBlocks) {
+			part := &message{blocks: blocks, thread: msg.thread, broadcast: msg.broadcast, ephemeral: msg.ephemeral}
+			if i == 0 {
+				part.text, _ = cutText(msg.text, maxMessageLength)
+			}
+			parts = append(parts, part)
+		}
+	} else {
+		for _, text := range splitText(msg.text, maxMessageLength) {
+			parts = append(parts, &message{text: text, thread: msg.thread, broadcast: msg.broadcast, ephemeral: msg.ephemeral})
+		}
+	}
+
+	parts[0].replaceOriginal = msg.replaceOriginal
+	parts[len(parts)-1].attachments = msg.attachments
+	return parts
+}
+
+// splitText divides the text into chunks of at most limit bytes, at line
+// boundaries where possible. Code blocks spanning chunks are closed at the end
+// of a chunk and reopened at the start of the next one.
+func splitText(text string, limit int) []string {
+	if len(text) <= limit {
+		return []string{text}
+	}
+
+	var chunks []string
+	var current strings.Builder
+	inFence := false
+	start := 0
+
+	flush := func() {
+		chunk := strings.TrimSuffix(current.String(), newLine)
+		if inFence {
+			chunk += newLine + codeFence
+		}
+		chunks = append(chunks, chunk)
+
+		current.Reset()
+		if inFence {
+			current.WriteString(codeFence + newLine)
+		}
+		start = current.Len()
+	}
+
+	for _, line := range strings.SplitAfter(text, newLine) {
+		opensOrClosesFence := strings.Count(line, codeFence)%2 == 1
+
+		for len(line) > 0 {
+			room := limit - codeFenceAllowance - current.Len()
+			if len(line) <= room {
+				current.WriteString(line)
+				break
+			}
+			if current.Len() > start {
+				flush()
+				continue
+			}
+
+			// the line alone does not fit in a message
+			cut := runeBoundary(line, room)
+			current.WriteString(line[:cut])
+			line = line[cut:]
+			flush()
+		}
+
+		if opensOrClosesFence {
+			inFence = !inFence
+		}
+	}
+
+	if current.Len() > start {
+		chunks = append(chunks, current.String())
+	}
+	return chunks
+}
+
+// cutText returns the longest head of the text up to a line boundary that fits
+// in limit bytes, along with the rest of the text
+func cutText(text string, limit int) (string, string) {
+	if len(text) <= limit {
+		return text, empty
+	}
+
+	budget := limit - codeFenceAllowance
+	cut := strings.LastIndex(text[:budget], newLine)
+	if cut <= 0 {
+		cut = runeBoundary(text, budget)
+	}
+
+	head, rest := text[:cut], strings.TrimPrefix(text[cut:], newLine)
+	if strings.Count(head, codeFence)%2 == 1 {
+		head += newLine + codeFence
+	}
+	return head, rest
+}
+
+// runeBoundary returns the largest index not above limit that does not split a
+// multi-byte character
+func runeBoundary(text string, limit int) int {
+	if limit >= len(text) {
+		return len(text)
+	}
+	for cut := limit; cut > 0; cut-- {
+		if utf8.RuneStart(text[cut]) {
+			return cut
+		}
+	}
+	return limit
+}
+
+// splitBlocks divides the blocks into groups of at most limit blocks
+func splitBlocks(blocks []slack.Block, limit int) [][]slack.Block {
+	var groups [][]slack.Block
+	for len(blocks) > limit {
+		groups = append(groups, blocks[:limit])
+		blocks = blocks[limit:]
+	}
+	return append(groups, blocks)
+}
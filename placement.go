@@ -0,0 +1,58 @@
+package slacker
+
+import "context"
+
+// ReplyPlacement instructs Slacker on whether replies are posted in a thread
+// or in the channel.
+type ReplyPlacement int
+
+const (
+	// ReplyPlacementWhereInvoked replies inside the thread the command was
+	// invoked from, and in the channel otherwise.
+	ReplyPlacementWhereInvoked ReplyPlacement = iota + 1
+
+	// ReplyPlacementAlwaysThread replies inside the thread of the message that
+	// triggered the command, starting one if needed.
+	ReplyPlacementAlwaysThread
+
+	// ReplyPlacementNeverThread replies in the channel, even when the command
+	// was invoked from a thread.
+	ReplyPlacementNeverThread
+)
+
+// replyPlacement returns the placement of the command being executed, falling
+// back to the bot's
+func replyPlacement(ctx context.Context) ReplyPlacement {
+	if cmd := commandFromContext(ctx); cmd != nil && cmd.Definition() != nil && cmd.Definition().ReplyPlacement != 0 {
+		return cmd.Definition().ReplyPlacement
+	}
+	if s := slackerFromContext(ctx); s != nil && s.replyPlacement != 0 {
+		return s.replyPlacement
+	}
+	return ReplyPlacementWhereInvoked
+}
+
+// replyThread returns the thread a reply to the event is posted in, empty to
+// post it in the channel. Forcing a thread overrides the placement.
+func replyThread(ctx context.Context, ev *MessageEvent, forceThread bool) string {
+	root := ev.TimeStamp
+	if ev.IsThread() {
+		root = ev.ThreadTimeStamp
+	}
+
+	if forceThread {
+		return root
+	}
+
+	switch replyPlacement(ctx) {
+	case ReplyPlacementAlwaysThread:
+		return root
+	case ReplyPlacementNeverThread:
+		return empty
+	default:
+		if ev.IsThread() {
+			return ev.ThreadTimeStamp
+		}
+		return empty
+	}
+}
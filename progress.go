@@ -0,0 +1,219 @@
+package slacker
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// progressUpdateInterval keeps updates well within the chat.update rate
+	// limits
+	progressUpdateInterval = 2 * time.Second
+	progressBarWidth       = 10
+	progressBarFilled      = "█"
+	progressBarEmpty       = "░"
+	progressTitleFormat    = "*%s*"
+	progressBarFormat      = "`%s` %d%%"
+	progressStepDone       = ":white_check_mark:"
+	progressStepRunning    = ":hourglass_flowing_sand:"
+	progressStepFailed     = ":x:"
+	progressSucceeded      = ":white_check_mark: *%s* completed"
+	progressFailed         = ":x: *%s* failed"
+)
+
+// ProgressTracker reports the progress of a long running command by updating
+// a single status message. Updates are throttled, and the message is finished
+// as a success or failure when the command handler returns unless Done or Fail
+// is called first.
+type ProgressTracker struct {
+	handle *MessageHandle
+	botCtx BotContext
+
+	// sending serializes the updates of the message
+	sending sync.Mutex
+
+	mutex    sync.Mutex
+	title    string
+	percent  int
+	text     string
+	steps    []string
+	failed   bool
+	finished bool
+	last     time.Time
+	timer    *time.Timer
+}
+
+// Progress posts a status message for a long running task and returns a
+// tracker to report its progress
+func Progress(response ResponseWriter, title string) (*ProgressTracker, error) {
+	writer, ok := response.(interface {
+		Progress(title string) (*ProgressTracker, error)
+	})
+	if !ok {
+		return nil, ErrUnsupportedResponseWriter
+	}
+	return writer.Progress(title)
+}
+
+// Progress posts a status message for a long running task and returns a
+// tracker to report its progress
+func (r *response) Progress(title string) (*ProgressTracker, error) {
+	tracker := &ProgressTracker{botCtx: r.botCtx, title: title, percent: -1}
+
+	handle, err := r.ReplyWithHandle(tracker.render())
+	if err != nil {
+		return nil, err
+	}
+	tracker.handle = handle
+	tracker.last = time.Now()
+
+	if exec := executionFromContext(r.botCtx.Context()); exec != nil {
+		exec.track(tracker)
+	}
+	return tracker, nil
+}
+
+// Progress reports the percentage of the task done, along with a description
+// of the current work
+func (p *ProgressTracker) Progress(percent int, text string) {
+	if percent < 0 {
+		percent = 0
+	}
+	if percent > 100 {
+		percent = 100
+	}
+
+	p.mutex.Lock()
+	p.percent, p.text = percent, text
+	p.mutex.Unlock()
+
+	p.schedule()
+}
+
+// Step reports that the task moved on to the named step, completing the
+// previous one
+func (p *ProgressTracker) Step(name string) {
+	p.mutex.Lock()
+	p.steps = append(p.steps, name)
+	p.mutex.Unlock()
+
+	p.schedule()
+}
+
+// Done finishes the task successfully, replacing the status with the text if
+// it is not empty
+func (p *ProgressTracker) Done(text string) {
+	p.finish(false, text)
+}
+
+// Fail finishes the task with an error
+func (p *ProgressTracker) Fail(err error) {
+	text := empty
+	if err != nil {
+		text = builtinText(p.botCtx, TemplateError, map[string]string{"Error": err.Error()})
+	}
+	p.finish(true, text)
+}
+
+// schedule updates the message now, or once the update interval has passed
+// since the last update
+func (p *ProgressTracker) schedule() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.finished || p.timer != nil {
+		return
+	}
+
+	wait := progressUpdateInterval - time.Since(p.last)
+	if wait < 0 {
+		wait = 0
+	}
+	p.timer = time.AfterFunc(wait, p.flush)
+}
+
+// flush sends the latest state of the task
+func (p *ProgressTracker) flush() {
+	p.sending.Lock()
+	defer p.sending.Unlock()
+
+	p.mutex.Lock()
+	p.timer = nil
+	if p.finished {
+		p.mutex.Unlock()
+		return
+	}
+	text := p.render()
+	p.last = time.Now()
+	p.mutex.Unlock()
+
+	if err := p.handle.Update(text); err != nil {
+		fmt.Printf("failed updating progress: %v\n", err)
+	}
+}
+
+// finish sends the final state of the task, once
+func (p *ProgressTracker) finish(failed bool, text string) {
+	p.sending.Lock()
+	defer p.sending.Unlock()
+
+	p.mutex.Lock()
+	if p.finished {
+		p.mutex.Unlock()
+		return
+	}
+	p.finished, p.failed = true, failed
+	if p.timer != nil {
+		p.timer.Stop()
+		p.timer = nil
+	}
+
+	final := p.render()
+	if len(text) > 0 {
+		final += newLine + text
+	}
+	p.mutex.Unlock()
+
+	if err := p.handle.Update(final); err != nil {
+		fmt.Printf("failed updating progress: %v\n", err)
+	}
+}
+
+// render formats the state of the task, the caller must hold the mutex
+func (p *ProgressTracker) render() string {
+	var lines []string
+	switch {
+	case p.finished && p.failed:
+		lines = append(lines, fmt.Sprintf(progressFailed, p.title))
+	case p.finished:
+		lines = append(lines, fmt.Sprintf(progressSucceeded, p.title))
+	default:
+		lines = append(lines, fmt.Sprintf(progressTitleFormat, p.title))
+	}
+
+	if p.percent >= 0 && !(p.finished && !p.failed) {
+		filled := p.percent * progressBarWidth / 100
+		bar := strings.Repeat(progressBarFilled, filled) + strings.Repeat(progressBarEmpty, progressBarWidth-filled)
+		line := fmt.Sprintf(progressBarFormat, bar, p.percent)
+		if len(p.text) > 0 {
+			line += space + p.text
+		}
+		lines = append(lines, line)
+	}
+
+	for i, step := range p.steps {
+		marker := progressStepDone
+		if i == len(p.steps)-1 {
+			switch {
+			case p.finished && p.failed:
+				marker = progressStepFailed
+			case !p.finished:
+				marker = progressStepRunning
+			}
+		}
+		lines = append(lines, marker+space+step)
+	}
+	return strings.Join(lines, newLine)
+}
@@ -0,0 +1,184 @@
+package slacker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/slackevents"
+)
+
+const (
+	reactionCommandType = "reaction_command"
+)
+
+// Reaction contains the reaction that triggered a reaction command and the
+// message it was added to. It is the Data of the command's MessageEvent.
+type Reaction struct {
+	// Emoji is the name of the reaction, without colons
+	Emoji string
+
+	// User is the ID of the user who reacted
+	User string
+
+	// Message is the message the reaction was added to, its User is the
+	// author of the message
+	Message *slack.Message
+
+	// Permalink links to the message, empty if it could not be fetched
+	Permalink string
+
+	Event *slackevents.ReactionAddedEvent
+}
+
+// ReactionCommand registers a command triggered by adding the emoji reaction,
+// eg. "ticket", to a message. The event of the command describes the message
+// and its Data is a *Reaction. Replies are posted in the thread of the message
+// unless the definition sets another ReplyPlacement.
+func (s *Slacker) ReactionCommand(emoji string, definition *CommandDefinition) {
+	s.ReactionCommandWithIncludeChannels(emoji, definition, defaultIncludeChannelIds)
+}
+
+// ReactionCommandWithIncludeChannels registers a reaction command with include
+// channels filter
+func (s *Slacker) ReactionCommandWithIncludeChannels(emoji string, definition *CommandDefinition, includeChannelIds []string) {
+	reactionDefinition := *definition
+	if reactionDefinition.ReplyPlacement == 0 {
+		reactionDefinition.ReplyPlacement = ReplyPlacementAlwaysThread
+	}
+
+	usage := emojiUsage(emoji)
+	s.reactionCommands = append(s.reactionCommands, NewBotCommand(usage, &reactionDefinition, false, includeChannelIds))
+}
+
+// emojiUsage returns the usage of a reaction command, eg. ":ticket:"
+func emojiUsage(emoji string) string {
+	return colon + strings.Trim(emoji, colon) + colon
+}
+
+func (s *Slacker) handleReactionAdded(ctx context.Context, event *slackevents.ReactionAddedEvent) {
+	if event.Item.Type != "message" || event.User == s.botUserID() {
+		return
+	}
+
+	usage := emojiUsage(event.Reaction)
+	for _, cmd := range s.reactionCommands {
+		if cmd.Usage() != usage || !cmd.ContainsChannel(event.Item.Channel) {
+			continue
+		}
+
+		msg, err := s.reactedMessage(event.Item.Channel, event.Item.Timestamp)
+		if err != nil {
+			s.reportError(fmt.Errorf("unable to fetch the message reacted to with %s: %v", usage, err))
+			return
+		}
+
+		reaction := &Reaction{Emoji: event.Reaction, User: event.User, Message: msg, Event: event}
+		reaction.Permalink, err = s.client.GetPermalink(&slack.PermalinkParameters{Channel: event.Item.Channel, Ts: msg.Timestamp})
+		if err != nil {
+			fmt.Printf("unable to get permalink of %s: %v\n", msg.Timestamp, err)
+		}
+
+		ev := &MessageEvent{
+			Channel:         event.Item.Channel,
+			User:            event.User,
+			Text:            msg.Text,
+			TimeStamp:       msg.Timestamp,
+			ThreadTimeStamp: msg.ThreadTimestamp,
+			Data:            reaction,
+			Type:            reactionCommandType,
+		}
+		if !s.channelPolicyAllows(cmd, ev) {
+			continue
+		}
+
+		botCtx := s.botContextConstructor(withExecution(withSlacker(ctx, s), cmd), s.client, s.socketModeClient, ev)
+		response := s.responseConstructor(botCtx)
+		request := s.requestConstructor(botCtx, nil, nil)
+		if cmd.Definition().AuthorizationFunc != nil && !cmd.Definition().AuthorizationFunc(botCtx, request) {
+			s.reportUnauthorized(botCtx, response)
+			return
+		}
+
+		select {
+		case s.commandChannel <- NewCommandEvent(cmd.Usage(), nil, ev):
+		default:
+			// full channel, dropped event
+		}
+
+		s.executeCommand(cmd, botCtx, request, response)
+		return
+	}
+}
+
+// reactedMessage fetches a message of the channel. Replies in threads are only
+// returned by conversations.replies, after the parent message.
+func (s *Slacker) reactedMessage(channel string, timestamp string) (*slack.Message, error) {
+	history, err := s.client.GetConversationHistory(&slack.GetConversationHistoryParameters{
+		ChannelID: channel,
+		Latest:    timestamp,
+		Inclusive: true,
+		Limit:     1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(history.Messages) > 0 && history.Messages[0].Timestamp == timestamp {
+		return &history.Messages[0], nil
+	}
+
+	replies, _, _, err := s.client.GetConversationReplies(&slack.GetConversationRepliesParameters{
+		ChannelID: channel,
+		Timestamp: timestamp,
+		Oldest:    timestamp,
+		Inclusive: true,
+		Limit:     2,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i := range replies {
+		if replies[i].Timestamp == timestamp {
+			return &replies[i], nil
+		}
+	}
+	return nil, fmt.Errorf("message %s not found in %s", timestamp, channel)
+}
+
+// botUserID returns the user ID of the bot, to ignore its own reactions
+func (s *Slacker) botUserID() string {
+	s.botUserOnce.Do(func() {
+		auth, err := s.client.AuthTest()
+		if err != nil {
+			fmt.Printf("unable to get the user ID of the bot: %v\n", err)
+			return
+		}
+		s.botUser = auth.UserID
+	})
+	return s.botUser
+}
+
+// reactionCommandsHelp lists the reaction commands in the help message
+func (s *Slacker) reactionCommandsHelp(botCtx BotContext) string {
+	helpMessage := empty
+	for _, cmd := range s.reactionCommands {
+		if cmd.Definition().HideHelp {
+			continue
+		}
+
+		helpMessage += cmd.Usage()
+		if len(cmd.Definition().Description) > 0 {
+			helpMessage += space + dash + space + fmt.Sprintf(italicMessageFormat, cmd.Definition().Description)
+		}
+		if cmd.Definition().AuthorizationFunc != nil {
+			helpMessage += space + fmt.Sprintf(codeMessageFormat, star)
+		}
+		helpMessage += newLine
+	}
+
+	if len(helpMessage) == 0 {
+		return empty
+	}
+	return newLine + builtinText(botCtx, TemplateHelpReactions, nil) + newLine + helpMessage
+}
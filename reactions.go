@@ -0,0 +1,72 @@
+package slacker
+
+import "fmt"
+
+// LifecycleReactions are added to the message that triggered a command to show
+// its progress. Started is added when the command matches and swapped for
+// Succeeded or Failed once it returns. A command fails when it reports an
+// error through its ResponseWriter or panics. Empty reactions are skipped.
+type LifecycleReactions struct {
+	Started   string
+	Succeeded string
+	Failed    string
+}
+
+// lifecycleReactions returns the reactions of the command, falling back to
+// the bot's
+func (s *Slacker) lifecycleReactions(cmd BotCommand) *LifecycleReactions {
+	if reactions := cmd.Definition().LifecycleReactions; reactions != nil {
+		return reactions
+	}
+	return s.defaultLifecycleReactions
+}
+
+// executeCommand executes the command, marking the triggering message with the
+// lifecycle reactions when configured and finishing the progress trackers the
+// handler left running
+func (s *Slacker) executeCommand(cmd BotCommand, botCtx BotContext, request Request, response ResponseWriter) {
+	reactions := s.lifecycleReactions(cmd)
+	if len(botCtx.Event().TimeStamp) == 0 {
+		reactions = nil
+	}
+
+	if reactions != nil {
+		s.react(response, reactions.Started)
+	}
+
+	// a panicking handler leaves failed set
+	failed := true
+	defer func() {
+		if reactions != nil {
+			s.unreact(response, reactions.Started)
+			if failed {
+				s.react(response, reactions.Failed)
+			} else {
+				s.react(response, reactions.Succeeded)
+			}
+		}
+	}()
+
+	exec := executionFromContext(botCtx.Context())
+	failed = exec.run(func() {
+		cmd.Execute(botCtx, request, response)
+	})
+}
+
+func (s *Slacker) react(response ResponseWriter, emoji string) {
+	if len(emoji) == 0 {
+		return
+	}
+	if err := React(response, emoji); err != nil {
+		fmt.Printf("unable to add reaction %q: %v\n", emoji, err)
+	}
+}
+
+func (s *Slacker) unreact(response ResponseWriter, emoji string) {
+	if len(emoji) == 0 {
+		return
+	}
+	if err := Unreact(response, emoji); err != nil {
+		fmt.Printf("unable to remove reaction %q: %v\n", emoji, err)
+	}
+}
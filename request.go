@@ -35,6 +35,9 @@ func (r *request) Param(key string) string {
 
 // StringParam attempts to look up a string value by key. If not found, return the default string value
 func (r *request) StringParam(key string, defaultValue string) string {
+	if r.match == nil {
+		return defaultValue
+	}
 	re, err := r.match.String(key)
 	if err != nil {
 		return defaultValue
@@ -44,6 +47,9 @@ func (r *request) StringParam(key string, defaultValue string) string {
 
 // IntegerParam attempts to look up a integer value by key. If not found, return the default integer value
 func (r *request) IntegerParam(key string, defaultValue int) int {
+	if r.match == nil {
+		return defaultValue
+	}
 	re, err := r.match.Integer(key)
 	if err != nil {
 		return defaultValue
@@ -1,21 +1,31 @@
 package slacker
 
 import (
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/slack-go/slack"
 )
 
-const (
-	errorFormat = "*Error:* _%s_"
-)
-
-// A ResponseWriter interface is used to respond to an event
+// A ResponseWriter interface is used to respond to an event. The response
+// writer created by NewResponse also supports the helpers taking a writer,
+// such as Ask, ReplyDM and Upload. Custom writers support a helper by
+// implementing the method of the same name and signature.
 type ResponseWriter interface {
 	Reply(text string, options ...ReplyOption) error
 	ReportError(err error, options ...ReportErrorOption)
 }
 
+// ErrNoChannel is returned when replying to an event that has neither a
+// channel nor a user to send a direct message to
+var ErrNoChannel = errors.New("event has no channel or user to reply to")
+
+// ErrUnsupportedResponseWriter is returned by the helpers taking a
+// ResponseWriter when the writer does not implement the operation
+var ErrUnsupportedResponseWriter = errors.New("response writer does not support this operation")
+
 // NewResponse creates a new response structure
 func NewResponse(botCtx BotContext) ResponseWriter {
 	return &response{botCtx: botCtx}
@@ -27,45 +37,454 @@ type response struct {
 
 // ReportError sends back a formatted error message to the channel where we received the event from
 func (r *response) ReportError(err error, options ...ReportErrorOption) {
+	if exec := executionFromContext(r.botCtx.Context()); exec != nil {
+		exec.fail()
+	}
+	if s := slackerFromContext(r.botCtx.Context()); s != nil {
+		options = append([]ReportErrorOption{WithEphemeralError(s.ephemeralErrors)}, options...)
+	}
 	defaults := NewReportErrorDefaults(options...)
 
-	client := r.botCtx.Client()
 	ev := r.botCtx.Event()
 
-	opts := []slack.MsgOption{
-		slack.MsgOptionText(fmt.Sprintf(errorFormat, err.Error()), false),
+	msg := &message{
+		text:      builtinText(r.botCtx, TemplateError, map[string]string{"Error": err.Error()}),
+		ephemeral: defaults.Ephemeral,
 	}
-	if defaults.ThreadResponse {
-		opts = append(opts, slack.MsgOptionTS(ev.TimeStamp))
-	}
-	_, _, err = client.PostMessage(ev.Channel, opts...)
+	msg.thread = replyThread(r.botCtx.Context(), ev, defaults.ThreadResponse)
+
+	_, err = r.send(ev, msg)
 	if err != nil {
 		fmt.Printf("failed posting message: %v\n", err)
 	}
 }
 
 // Reply send a attachments to the current channel with a message
-func (r *response) Reply(message string, options ...ReplyOption) error {
+func (r *response) Reply(text string, options ...ReplyOption) error {
+	_, err := r.ReplyWithHandle(text, options...)
+	return err
+}
+
+// ReplyWithHandle replies like Reply and returns a handle to update, delete or
+// follow up on the posted message
+func ReplyWithHandle(response ResponseWriter, text string, options ...ReplyOption) (*MessageHandle, error) {
+	writer, ok := response.(interface {
+		ReplyWithHandle(text string, options ...ReplyOption) (*MessageHandle, error)
+	})
+	if !ok {
+		return nil, ErrUnsupportedResponseWriter
+	}
+	return writer.ReplyWithHandle(text, options...)
+}
+
+// ReplyWithHandle replies like Reply and returns a handle to update, delete or
+// follow up on the posted message
+func (r *response) ReplyWithHandle(text string, options ...ReplyOption) (*MessageHandle, error) {
 	defaults := NewReplyDefaults(options...)
 
-	client := r.botCtx.Client()
+	ev := r.botCtx.Event()
+	if ev == nil {
+		return nil, fmt.Errorf("unable to get message event details")
+	}
+
+	msg := &message{
+		text:            text,
+		attachments:     defaults.Attachments,
+		blocks:          defaults.Blocks,
+		ephemeral:       defaults.Ephemeral,
+		replaceOriginal: defaults.ReplaceOriginal,
+		thread:          replyThread(r.botCtx.Context(), ev, defaults.ThreadResponse),
+		broadcast:       defaults.Broadcast,
+	}
+
+	return r.send(ev, msg)
+}
+
+// ReplyTemplate replies with the message rendered from the text and blocks
+// templates of the name, in the locale of the user who triggered the event
+func ReplyTemplate(response ResponseWriter, name string, data interface{}, options ...ReplyOption) error {
+	writer, ok := response.(interface {
+		ReplyTemplate(name string, data interface{}, options ...ReplyOption) error
+	})
+	if !ok {
+		return ErrUnsupportedResponseWriter
+	}
+	return writer.ReplyTemplate(name, data, options...)
+}
+
+// ReplyTemplate replies with the message rendered from the text and blocks
+// templates of the name, in the locale of the user who triggered the event
+func (r *response) ReplyTemplate(name string, data interface{}, options ...ReplyOption) error {
+	s := slackerFromContext(r.botCtx.Context())
+	if s == nil {
+		return fmt.Errorf("unable to render templates outside of a Slacker handler")
+	}
+
 	ev := r.botCtx.Event()
 	if ev == nil {
 		return fmt.Errorf("unable to get message event details")
 	}
+	locale := s.userLocale(ev.User)
 
-	opts := []slack.MsgOption{
-		slack.MsgOptionText(message, false),
-		slack.MsgOptionAttachments(defaults.Attachments...),
-		slack.MsgOptionBlocks(defaults.Blocks...),
+	text, textErr := s.Templates().Render(locale, name, data)
+	blocks, blocksErr := s.Templates().RenderBlocks(locale, name, data)
+	if textErr != nil && blocksErr != nil {
+		return fmt.Errorf("unable to render %q: %v, %v", name, textErr, blocksErr)
 	}
-	if defaults.ThreadResponse {
-		opts = append(opts, slack.MsgOptionTS(ev.TimeStamp))
+	if blocksErr == nil {
+		options = append(options, WithBlocks(blocks))
 	}
+	return r.Reply(text, options...)
+}
 
-	_, _, err := client.PostMessage(
-		ev.Channel,
-		opts...,
-	)
-	return err
+// ReplyDM sends the message privately to the user who triggered the event, in
+// their direct message with the bot
+func ReplyDM(response ResponseWriter, text string, options ...ReplyOption) (*MessageHandle, error) {
+	writer, ok := response.(interface {
+		ReplyDM(text string, options ...ReplyOption) (*MessageHandle, error)
+	})
+	if !ok {
+		return nil, ErrUnsupportedResponseWriter
+	}
+	return writer.ReplyDM(text, options...)
+}
+
+// ReplyDM sends the message privately to the user who triggered the event, in
+// their direct message with the bot
+func (r *response) ReplyDM(text string, options ...ReplyOption) (*MessageHandle, error) {
+	defaults := NewReplyDefaults(options...)
+
+	ev := r.botCtx.Event()
+	if ev == nil || len(ev.User) == 0 {
+		return nil, fmt.Errorf("unable to get the user of the event")
+	}
+
+	channel, err := openDirectMessage(r.botCtx.Client(), ev.User)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := &message{text: text, attachments: defaults.Attachments, blocks: defaults.Blocks}
+
+	var first *MessageHandle
+	for _, part := range splitMessage(msg) {
+		handle, err := r.post(&MessageEvent{Channel: channel}, part)
+		if err != nil {
+			return first, err
+		}
+		if first == nil {
+			first = handle
+		}
+	}
+
+	if defaults.DMNotice && len(ev.Channel) > 0 && channel != ev.Channel {
+		notice := &message{
+			text:   builtinText(r.botCtx, TemplateDMNotice, map[string]string{"User": ev.User}),
+			thread: replyThread(r.botCtx.Context(), ev, defaults.ThreadResponse),
+		}
+		if _, err := r.send(ev, notice); err != nil {
+			fmt.Printf("failed posting direct message notice: %v\n", err)
+		}
+	}
+	return first, nil
+}
+
+// message is an outgoing reply, kept apart from the slack options so that it
+// can be delivered through the Web API, a slash command acknowledgement or a
+// response URL
+type message struct {
+	text            string
+	attachments     []slack.Attachment
+	blocks          []slack.Block
+	thread          string
+	broadcast       bool
+	ephemeral       bool
+	replaceOriginal bool
+}
+
+// options converts the message into Web API options, leaving out the thread
+func (m *message) options() []slack.MsgOption {
+	return []slack.MsgOption{
+		slack.MsgOptionText(m.text, false),
+		slack.MsgOptionAttachments(m.attachments...),
+		slack.MsgOptionBlocks(m.blocks...),
+	}
+}
+
+// responseType returns the slash command response type of the message
+func (m *message) responseType() string {
+	if m.ephemeral {
+		return slack.ResponseTypeEphemeral
+	}
+	return slack.ResponseTypeInChannel
+}
+
+// send delivers the message in response to the event and returns a handle to
+// the posted message. Messages exceeding the size limits of Slack are split or
+// partly uploaded as a snippet according to the bot's overflow policy, in which
+// case the handle refers to the first message.
+func (r *response) send(ev *MessageEvent, msg *message) (*MessageHandle, error) {
+	// events without a channel, eg. view submissions and global shortcuts,
+	// are replied to by direct message
+	if len(ev.Channel) == 0 {
+		if len(ev.User) == 0 {
+			return nil, ErrNoChannel
+		}
+		channel, err := openDirectMessage(r.botCtx.Client(), ev.User)
+		if err != nil {
+			return nil, err
+		}
+		dm := *ev
+		dm.Channel = channel
+		ev = &dm
+	}
+
+	var overflow string
+	if r.snippetOverflow(msg) {
+		trimmed := *msg
+		trimmed.text, overflow = cutText(msg.text, maxMessageLength)
+		msg = &trimmed
+	}
+
+	var first *MessageHandle
+	for _, part := range splitMessage(msg) {
+		handle, err := r.deliver(ev, part)
+		if err != nil {
+			return first, err
+		}
+		if first == nil {
+			first = handle
+		}
+	}
+
+	if len(overflow) > 0 {
+		params := slack.FileUploadParameters{
+			Content:         overflow,
+			Filename:        overflowFilename,
+			Filetype:        overflowFiletype,
+			Channels:        []string{ev.Channel},
+			ThreadTimestamp: msg.thread,
+		}
+		if _, err := r.botCtx.Client().UploadFileContext(r.botCtx.Context(), params); err != nil {
+			return first, err
+		}
+	}
+	return first, nil
+}
+
+// snippetOverflow indicates if the text overflowing the message should be
+// uploaded as a snippet
+func (r *response) snippetOverflow(msg *message) bool {
+	s := slackerFromContext(r.botCtx.Context())
+	if s == nil || s.overflowPolicy != OverflowPolicySnippet {
+		return false
+	}
+	return !msg.ephemeral && len(msg.blocks) == 0 && len(msg.text) > maxMessageLength
+}
+
+// deliver sends a message within the size limits of Slack
+func (r *response) deliver(ev *MessageEvent, msg *message) (*MessageHandle, error) {
+	if responder := slashCommandResponderFromContext(r.botCtx.Context()); responder != nil {
+		return r.respondToSlashCommand(ev, responder, msg)
+	}
+	if callback, ok := ev.Data.(*slack.InteractionCallback); ok && len(callback.ResponseURL) > 0 && len(msg.thread) == 0 {
+		return r.respondToInteraction(ev, callback.ResponseURL, msg)
+	}
+	return r.post(ev, msg)
+}
+
+// respondToInteraction answers through the response URL of the interaction,
+// which also reaches channels the bot is not a member of. Replies in threads
+// are posted instead, as are replies once the response URL expired.
+func (r *response) respondToInteraction(ev *MessageEvent, responseURL string, msg *message) (*MessageHandle, error) {
+	opts := append(msg.options(), slack.MsgOptionResponseURL(responseURL, msg.responseType()))
+	if msg.replaceOriginal {
+		opts = append(opts, slack.MsgOptionReplaceOriginal(responseURL))
+	}
+
+	_, _, err := r.botCtx.Client().PostMessage(ev.Channel, opts...)
+	if err != nil {
+		fmt.Printf("failed responding through response URL, posting instead: %v\n", err)
+		return r.post(ev, msg)
+	}
+
+	// only the original message can be addressed through the response URL
+	handle := &MessageHandle{client: r.botCtx.Client(), channel: ev.Channel, ephemeral: msg.ephemeral}
+	if msg.replaceOriginal {
+		handle.responseURL = responseURL
+	}
+	return handle, nil
+}
+
+// respondToSlashCommand answers inside the acknowledgement while it has not
+// been sent, then through the response URL. Once the response URL expired the
+// message is posted to the channel instead.
+func (r *response) respondToSlashCommand(ev *MessageEvent, responder *slashCommandResponder, msg *message) (*MessageHandle, error) {
+	payload := &slashCommandPayload{
+		ResponseType: msg.responseType(),
+		Text:         msg.text,
+		Attachments:  msg.attachments,
+		Blocks:       msg.blocks,
+	}
+	responseURL := responder.command.ResponseURL
+	handle := &MessageHandle{
+		client:      r.botCtx.Client(),
+		channel:     ev.Channel,
+		ephemeral:   msg.ephemeral,
+		responseURL: responseURL,
+	}
+	if !msg.replaceOriginal && responder.ack(payload) {
+		return handle, nil
+	}
+
+	opts := append(msg.options(), slack.MsgOptionResponseURL(responseURL, msg.responseType()))
+	if msg.replaceOriginal {
+		opts = append(opts, slack.MsgOptionReplaceOriginal(responseURL))
+	}
+
+	_, _, err := r.botCtx.Client().PostMessage(ev.Channel, opts...)
+	if err == nil {
+		return handle, nil
+	}
+
+	fmt.Printf("failed responding through response URL, posting instead: %v\n", err)
+	return r.post(ev, msg)
+}
+
+// post sends the message to the channel of the event, inside its thread if it
+// has one. Ephemeral messages are only shown to the user who triggered the
+// event; in direct messages or without a user they are posted normally, and if
+// the bot cannot post ephemeral messages in the channel they are sent to the
+// user as a direct message instead.
+func (r *response) post(ev *MessageEvent, msg *message) (*MessageHandle, error) {
+	client := r.botCtx.Client()
+	handle := &MessageHandle{client: client, channel: ev.Channel, thread: msg.thread}
+
+	opts := msg.options()
+	if len(msg.thread) > 0 {
+		opts = append(opts, slack.MsgOptionTS(msg.thread))
+		if msg.broadcast {
+			opts = append(opts, slack.MsgOptionBroadcast())
+		}
+	}
+
+	var err error
+	if !msg.ephemeral || len(ev.User) == 0 || strings.HasPrefix(ev.Channel, directChannelMarker) {
+		if tracker := replyTrackerFromContext(r.botCtx.Context()); tracker != nil {
+			handle.channel, handle.timestamp, err = postTracked(client, tracker, ev.Channel, msg, opts)
+			return handle, err
+		}
+		handle.channel, handle.timestamp, err = client.PostMessage(ev.Channel, opts...)
+		return handle, err
+	}
+
+	handle.ephemeral = true
+	handle.timestamp, err = client.PostEphemeral(ev.Channel, ev.User, opts...)
+	if err == nil || !ephemeralUnreachable(err) {
+		return handle, err
+	}
+
+	channel, openErr := openDirectMessage(client, ev.User)
+	if openErr != nil {
+		return nil, err
+	}
+
+	handle = &MessageHandle{client: client}
+	handle.channel, handle.timestamp, err = client.PostMessage(channel, msg.options()...)
+	return handle, err
+}
+
+// ephemeralUnreachable indicates if an ephemeral message failed because the
+// bot or the user cannot see the channel, in which case it is sent by direct
+// message instead
+func ephemeralUnreachable(err error) bool {
+	switch err.Error() {
+	case "user_not_in_channel", "channel_not_found":
+		return true
+	default:
+		return false
+	}
+}
+
+// openDirectMessage opens, or reuses, the direct message with the user
+func openDirectMessage(client *slack.Client, userID string) (string, error) {
+	channel, _, _, err := client.OpenConversation(&slack.OpenConversationParameters{Users: []string{userID}})
+	if err != nil {
+		return empty, err
+	}
+	return channel.ID, nil
+}
+
+// React adds an emoji reaction, eg. "eyes", to the message the event
+// originated from
+func React(response ResponseWriter, emoji string) error {
+	writer, ok := response.(interface{ React(emoji string) error })
+	if !ok {
+		return ErrUnsupportedResponseWriter
+	}
+	return writer.React(emoji)
+}
+
+// React adds an emoji reaction, eg. "eyes", to the message the event
+// originated from
+func (r *response) React(emoji string) error {
+	ref, err := r.eventRef()
+	if err != nil {
+		return err
+	}
+	return r.botCtx.Client().AddReaction(emoji, ref)
+}
+
+// Unreact removes an emoji reaction of the bot from the message the event
+// originated from
+func Unreact(response ResponseWriter, emoji string) error {
+	writer, ok := response.(interface{ Unreact(emoji string) error })
+	if !ok {
+		return ErrUnsupportedResponseWriter
+	}
+	return writer.Unreact(emoji)
+}
+
+// Unreact removes an emoji reaction of the bot from the message the event
+// originated from
+func (r *response) Unreact(emoji string) error {
+	ref, err := r.eventRef()
+	if err != nil {
+		return err
+	}
+	return r.botCtx.Client().RemoveReaction(emoji, ref)
+}
+
+// eventRef refers to the message the event originated from, slash commands
+// and scheduled jobs have none
+func (r *response) eventRef() (slack.ItemRef, error) {
+	ev := r.botCtx.Event()
+	if ev == nil || len(ev.TimeStamp) == 0 {
+		return slack.ItemRef{}, ErrMessageUnavailable
+	}
+	return slack.NewRefToMessage(ev.Channel, ev.TimeStamp), nil
+}
+
+// Ask posts a question where the event was received and waits for the next
+// message of the same user in the same thread or direct message. The reply is
+// not matched against the commands.
+func Ask(response ResponseWriter, question string, timeout time.Duration) (*MessageEvent, error) {
+	writer, ok := response.(interface {
+		Ask(question string, timeout time.Duration) (*MessageEvent, error)
+	})
+	if !ok {
+		return nil, ErrUnsupportedResponseWriter
+	}
+	return writer.Ask(question, timeout)
+}
+
+// Ask posts a question where the event was received and waits for the next
+// message of the same user in the same thread or direct message. The reply is
+// not matched against the commands.
+func (r *response) Ask(question string, timeout time.Duration) (*MessageEvent, error) {
+	s := slackerFromContext(r.botCtx.Context())
+	if s == nil {
+		return nil, fmt.Errorf("unable to ask outside of a Slacker handler")
+	}
+	return s.ask(r, question, timeout)
 }
@@ -0,0 +1,133 @@
+package slacker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	scheduledJobType = "scheduled_job"
+)
+
+// JobDefinition structure contains definition of a scheduled job
+type JobDefinition struct {
+	Description string
+
+	// Channel is the ID of the channel the job's ResponseWriter replies to
+	Channel string
+
+	// Location is the time zone the cron expression is evaluated in, unless
+	// the expression sets one with a CRON_TZ= prefix. Defaults to time.Local.
+	Location *time.Location
+
+	// Handler runs the job. Returned errors are reported to the error handler.
+	Handler func(botCtx BotContext, response ResponseWriter) error
+}
+
+type scheduledJob struct {
+	spec       string
+	definition *JobDefinition
+	schedule   cronSchedule
+	running    int32
+}
+
+type scheduler struct {
+	mutex sync.Mutex
+	jobs  []*scheduledJob
+	ctx   context.Context
+}
+
+// Schedule registers a job to run at the times described by the cron
+// expression. Standard five field expressions, descriptors such as @daily or
+// @every 1h and a CRON_TZ=<zone> prefix are supported. Jobs start and stop with
+// Listen, and a run is skipped while the previous one is still in progress.
+func (s *Slacker) Schedule(spec string, definition *JobDefinition) error {
+	if definition == nil {
+		return fmt.Errorf("job %q has no definition", spec)
+	}
+
+	schedule, err := parseCronSpec(spec, definition.Location)
+	if err != nil {
+		return err
+	}
+
+	job := &scheduledJob{spec: spec, definition: definition, schedule: schedule}
+
+	s.scheduler.mutex.Lock()
+	defer s.scheduler.mutex.Unlock()
+
+	s.scheduler.jobs = append(s.scheduler.jobs, job)
+	if s.scheduler.ctx != nil {
+		go s.runSchedule(s.scheduler.ctx, job)
+	}
+	return nil
+}
+
+// startScheduler starts every registered job until the context is done
+func (s *Slacker) startScheduler(ctx context.Context) {
+	s.scheduler.mutex.Lock()
+	defer s.scheduler.mutex.Unlock()
+
+	s.scheduler.ctx = ctx
+	for _, job := range s.scheduler.jobs {
+		go s.runSchedule(ctx, job)
+	}
+
+	go func() {
+		<-ctx.Done()
+		s.scheduler.mutex.Lock()
+		s.scheduler.ctx = nil
+		s.scheduler.mutex.Unlock()
+	}()
+}
+
+func (s *Slacker) runSchedule(ctx context.Context, job *scheduledJob) {
+	for {
+		next := job.schedule.next(time.Now())
+		if next.IsZero() {
+			s.reportError(fmt.Errorf("job %q will never run again", job.spec))
+			return
+		}
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+			if !atomic.CompareAndSwapInt32(&job.running, 0, 1) {
+				s.reportError(fmt.Errorf("skipping run of job %q, previous run is still in progress", job.spec))
+				continue
+			}
+			go s.runJob(ctx, job)
+		}
+	}
+}
+
+func (s *Slacker) runJob(ctx context.Context, job *scheduledJob) {
+	defer atomic.StoreInt32(&job.running, 0)
+	defer func() {
+		if r := recover(); r != nil {
+			s.reportError(fmt.Errorf("job %q panicked: %v", job.spec, r))
+		}
+	}()
+
+	if job.definition.Handler == nil {
+		return
+	}
+
+	ev := &MessageEvent{
+		Channel: job.definition.Channel,
+		Type:    scheduledJobType,
+		Data:    job.definition,
+	}
+	botCtx := s.botContextConstructor(withSlacker(ctx, s), s.client, s.socketModeClient, ev)
+	response := s.responseConstructor(botCtx)
+
+	if err := job.definition.Handler(botCtx, response); err != nil {
+		s.reportError(fmt.Errorf("job %q failed: %v", job.spec, err))
+	}
+}
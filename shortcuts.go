@@ -0,0 +1,101 @@
+package slacker
+
+import (
+	"fmt"
+
+	"github.com/slack-go/slack"
+)
+
+// ShortcutDefinition structure contains definition of a global or message
+// shortcut
+type ShortcutDefinition struct {
+	// Name is shown in Slack's shortcut menus
+	Name        string
+	Description string
+	Handler     func(botCtx BotContext, shortcut *Shortcut, response ResponseWriter)
+
+	// HideHelp will cause this shortcut to not be shown when a user requests
+	// help.
+	HideHelp bool
+}
+
+// Shortcut contains the payload of a triggered shortcut
+type Shortcut struct {
+	CallbackID string
+	TriggerID  string
+
+	// Message is the message a message shortcut was triggered on, nil for
+	// global shortcuts
+	Message *slack.Message
+
+	Callback *slack.InteractionCallback
+}
+
+type shortcut struct {
+	callbackID string
+	definition *ShortcutDefinition
+	message    bool
+}
+
+// Shortcut registers a global shortcut, triggered from Slack's shortcut menu.
+// Global shortcuts have no channel to reply to, they usually open a modal or
+// reply by direct message.
+func (s *Slacker) Shortcut(callbackID string, definition *ShortcutDefinition) {
+	s.addShortcut(callbackID, definition, slack.InteractionTypeShortcut)
+}
+
+// MessageShortcut registers a shortcut triggered from the menu of a message,
+// which the handler receives
+func (s *Slacker) MessageShortcut(callbackID string, definition *ShortcutDefinition) {
+	s.addShortcut(callbackID, definition, slack.InteractionTypeMessageAction)
+}
+
+func (s *Slacker) addShortcut(callbackID string, definition *ShortcutDefinition, kind slack.InteractionType) {
+	s.shortcuts = append(s.shortcuts, &shortcut{
+		callbackID: callbackID,
+		definition: definition,
+		message:    kind == slack.InteractionTypeMessageAction,
+	})
+
+	s.Interaction(&InteractionDefinition{
+		Description: definition.Description,
+		Type:        kind,
+		CallbackID:  callbackID,
+		Handler: func(botCtx BotContext, interaction *Interaction, response ResponseWriter) {
+			if definition.Handler == nil {
+				return
+			}
+
+			callback := interaction.Callback
+			shortcut := &Shortcut{CallbackID: callbackID, TriggerID: callback.TriggerID, Callback: callback}
+			if kind == slack.InteractionTypeMessageAction {
+				shortcut.Message = &callback.Message
+			}
+			definition.Handler(botCtx, shortcut, response)
+		},
+	})
+}
+
+// shortcutsHelp lists the shortcuts in the help message
+func (s *Slacker) shortcutsHelp(botCtx BotContext) string {
+	helpMessage := empty
+	for _, shortcut := range s.shortcuts {
+		if shortcut.definition.HideHelp {
+			continue
+		}
+
+		helpMessage += fmt.Sprintf(boldMessageFormat, shortcut.definition.Name)
+		if len(shortcut.definition.Description) > 0 {
+			helpMessage += space + dash + space + fmt.Sprintf(italicMessageFormat, shortcut.definition.Description)
+		}
+		if shortcut.message {
+			helpMessage += space + fmt.Sprintf(codeMessageFormat, builtinText(botCtx, TemplateHelpMessageShortcut, nil))
+		}
+		helpMessage += newLine
+	}
+
+	if len(helpMessage) == 0 {
+		return empty
+	}
+	return newLine + builtinText(botCtx, TemplateHelpShortcuts, nil) + newLine + helpMessage
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"sync"
 
 	"github.com/slack-go/slack"
 	"github.com/slack-go/slack/slackevents"
@@ -18,6 +19,7 @@ const (
 	space               = " "
 	dash                = "-"
 	star                = "*"
+	colon               = ":"
 	newLine             = "\n"
 	invalidToken        = "invalid token"
 	helpCommand         = "(Bot|bot) help"
@@ -26,8 +28,6 @@ const (
 	codeMessageFormat   = "`%s`"
 	boldMessageFormat   = "*%s*"
 	italicMessageFormat = "_%s_"
-	quoteMessageFormat  = ">_*Example:* %s_"
-	authorizedUsersOnly = "Authorized users only"
 	slackBotUser        = "USLACKBOT"
 	botPrefix           = "(Bot|bot) "
 )
@@ -56,36 +56,70 @@ func NewClient(botToken, appToken string, options ...ClientOption) *Slacker {
 		socketmode.OptionDebug(defaults.Debug),
 	)
 	slacker := &Slacker{
-		client:             api,
-		socketModeClient:   smc,
-		commandChannel:     make(chan *CommandEvent, 100),
-		errUnauthorized:    errUnauthorized,
-		botInteractionMode: defaults.BotMode,
-		cleanEventInput:    defaultCleanEventInput,
+		client:                    api,
+		socketModeClient:          smc,
+		commandChannel:            make(chan *CommandEvent, 100),
+		errUnauthorized:           errUnauthorized,
+		botInteractionMode:        defaults.BotMode,
+		validationMode:            defaults.ValidationMode,
+		store:                     defaults.Store,
+		ephemeralErrors:           defaults.EphemeralErrors,
+		ephemeralHelp:             defaults.EphemeralHelp,
+		ephemeralUnauthorized:     defaults.EphemeralUnauthorized,
+		defaultLifecycleReactions: defaults.LifecycleReactions,
+		overflowPolicy:            defaults.OverflowPolicy,
+		templates:                 defaults.Templates,
+		builtinHome:               defaults.BuiltinHome,
+		editTracking:              defaults.EditTracking,
+		replyPlacement:            defaults.ReplyPlacement,
+		cleanEventInput:           defaultCleanEventInput,
 	}
 	return slacker
 }
 
 // Slacker contains the Slack API, botCommands, and handlers
 type Slacker struct {
-	client                  *slack.Client
-	socketModeClient        *socketmode.Client
-	botCommands             []BotCommand
-	botContextConstructor   func(ctx context.Context, api *slack.Client, client *socketmode.Client, evt *MessageEvent) BotContext
-	commandConstructor      func(usage string, definition *CommandDefinition) BotCommand
-	requestConstructor      func(botCtx BotContext, params []allot.Parameter, match allot.MatchInterface) Request
-	responseConstructor     func(botCtx BotContext) ResponseWriter
-	initHandler             func()
-	errorHandler            func(err string)
-	interactiveEventHandler func(*Slacker, *socketmode.Event, *slack.InteractionCallback)
-	helpDefinition          *CommandDefinition
-	defaultMessageHandler   func(botCtx BotContext, request Request, response ResponseWriter)
-	defaultEventHandler     func(interface{})
-	errUnauthorized         error
-	commandChannel          chan *CommandEvent
-	appID                   string
-	botInteractionMode      BotInteractionMode
-	cleanEventInput         func(in string) string
+	client                    *slack.Client
+	socketModeClient          *socketmode.Client
+	botCommands               []BotCommand
+	botContextConstructor     func(ctx context.Context, api *slack.Client, client *socketmode.Client, evt *MessageEvent) BotContext
+	commandConstructor        func(usage string, definition *CommandDefinition) BotCommand
+	requestConstructor        func(botCtx BotContext, params []allot.Parameter, match allot.MatchInterface) Request
+	responseConstructor       func(botCtx BotContext) ResponseWriter
+	initHandler               func()
+	errorHandler              func(err string)
+	interactiveEventHandler   func(*Slacker, *socketmode.Event, *slack.InteractionCallback)
+	interactions              []*interactionRoute
+	shortcuts                 []*shortcut
+	appHomeOpenedHandler      func(botCtx BotContext, event *slackevents.AppHomeOpenedEvent)
+	builtinHome               bool
+	editTracking              bool
+	eventHandlers             map[string][]EventHandler
+	reactionCommands          []BotCommand
+	unfurls                   []*unfurl
+	botUserOnce               sync.Once
+	botUser                   string
+	helpDefinition            *CommandDefinition
+	defaultMessageHandler     func(botCtx BotContext, request Request, response ResponseWriter)
+	defaultEventHandler       func(interface{})
+	errUnauthorized           error
+	commandChannel            chan *CommandEvent
+	appID                     string
+	botInteractionMode        BotInteractionMode
+	validationMode            ValidationMode
+	channelCache              channelInfoCache
+	conversations             conversationManager
+	store                     Store
+	scheduler                 scheduler
+	ephemeralErrors           bool
+	ephemeralHelp             bool
+	ephemeralUnauthorized     bool
+	defaultLifecycleReactions *LifecycleReactions
+	overflowPolicy            OverflowPolicy
+	replyPlacement            ReplyPlacement
+	templates                 *Templates
+	localeCache               userLocaleCache
+	cleanEventInput           func(in string) string
 }
 
 // BotCommands returns Bot Commands
@@ -103,6 +137,20 @@ func (s *Slacker) SocketMode() *socketmode.Client {
 	return s.socketModeClient
 }
 
+// Store returns the store handlers keep their state in
+func (s *Slacker) Store() Store {
+	return s.store
+}
+
+// Templates returns the registry of message templates, including the built-in
+// messages
+func (s *Slacker) Templates() *Templates {
+	if s.templates == nil {
+		return defaultTemplates
+	}
+	return s.templates
+}
+
 // Init handle the event when the bot is first connected
 func (s *Slacker) Init(initHandler func()) {
 	s.initHandler = initHandler
@@ -205,6 +253,15 @@ func (s *Slacker) CommandEvents() <-chan *CommandEvent {
 // Listen receives events from Slack and each is handled as needed
 func (s *Slacker) Listen(ctx context.Context) error {
 	s.prependHelpHandle()
+	s.ensureConstructors()
+
+	if err := s.validateCommands(); err != nil {
+		return err
+	}
+
+	// stop the event loop and the scheduled jobs however Listen returns
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
 
 	go func() {
 		for {
@@ -238,12 +295,31 @@ func (s *Slacker) Listen(ctx context.Context) error {
 						continue
 					}
 
+					handled := s.dispatchEvent(ctx, ev.InnerEvent.Type, ev.InnerEvent.Data)
+
 					switch ev.InnerEvent.Type {
 					case "message", "app_mention": // message-based events
 						go s.handleMessageEvent(ctx, ev.InnerEvent.Data, nil)
 
+					case EventReactionAdded:
+						if event, ok := ev.InnerEvent.Data.(*slackevents.ReactionAddedEvent); ok && len(s.reactionCommands) > 0 {
+							go s.handleReactionAdded(ctx, event)
+						}
+
+					case linkSharedType:
+						if event, ok := ev.InnerEvent.Data.(*slackevents.LinkSharedEvent); ok && len(s.unfurls) > 0 {
+							go s.handleLinkShared(ctx, event)
+						}
+
+					case appHomeOpenedType:
+						if event, ok := ev.InnerEvent.Data.(*slackevents.AppHomeOpenedEvent); ok && s.homeEnabled() {
+							go s.handleAppHomeOpened(ctx, event)
+						}
+
 					default:
-						fmt.Printf("unsupported inner event: %+v\n", ev.InnerEvent.Type)
+						if !handled {
+							fmt.Printf("unsupported inner event: %+v\n", ev.InnerEvent.Type)
+						}
 					}
 
 					s.socketModeClient.Ack(*evt.Request)
@@ -253,8 +329,14 @@ func (s *Slacker) Listen(ctx context.Context) error {
 						fmt.Printf("Ignored %+v\n", evt)
 						continue
 					}
-					s.socketModeClient.Ack(*evt.Request)
-					go s.handleMessageEvent(ctx, &callback, evt.Request)
+
+					// the acknowledgement is sent with the first reply of the
+					// handler, or empty once it returns or runs out of time
+					responder := newSlashCommandResponder(s.socketModeClient, &callback, evt.Request)
+					go func() {
+						defer responder.ack(nil)
+						s.handleMessageEvent(withSlashCommandResponder(ctx, responder), &callback, evt.Request)
+					}()
 				case socketmode.EventTypeInteractive:
 					callback, ok := evt.Data.(slack.InteractionCallback)
 					if !ok {
@@ -262,7 +344,7 @@ func (s *Slacker) Listen(ctx context.Context) error {
 						continue
 					}
 
-					go s.handleInteractiveEvent(s, &evt, &callback, evt.Request)
+					go s.handleInteractiveEvent(ctx, s, &evt, &callback, evt.Request)
 				default:
 					if s.defaultEventHandler != nil {
 						s.defaultEventHandler(evt)
@@ -274,11 +356,42 @@ func (s *Slacker) Listen(ctx context.Context) error {
 		}
 	}()
 
+	s.startScheduler(ctx)
+
 	// blocking call that handles listening for events and placing them in the
 	// Events channel as well as handling outgoing events.
 	return s.socketModeClient.RunContext(ctx)
 }
 
+func (s *Slacker) validateCommands() error {
+	if s.validationMode == ValidationModeOff {
+		return nil
+	}
+
+	result := s.Validate()
+	for _, warning := range result.Warnings {
+		fmt.Printf("command validation warning: %s\n", warning)
+	}
+
+	if s.validationMode == ValidationModeWarn {
+		for _, err := range result.Errors {
+			fmt.Printf("command validation error: %s\n", err)
+		}
+		return nil
+	}
+	return result.Err()
+}
+
+// reportError hands errors that cannot be returned to a caller to the error
+// handler, or prints them if none is set
+func (s *Slacker) reportError(err error) {
+	if s.errorHandler != nil {
+		s.errorHandler(err.Error())
+		return
+	}
+	fmt.Printf("%v\n", err)
+}
+
 func (s *Slacker) unsupportedEventReceived() {
 	s.socketModeClient.Debugf("unsupported Events API event received")
 }
@@ -316,14 +429,18 @@ func (s *Slacker) defaultHelp(botCtx BotContext, request Request, response Respo
 		helpMessage += newLine
 
 		for _, example := range command.Definition().Examples {
-			helpMessage += fmt.Sprintf(quoteMessageFormat, example) + newLine
+			helpMessage += builtinText(botCtx, TemplateHelpExample, map[string]string{"Example": example}) + newLine
 		}
 	}
 
 	if authorizedCommandAvailable {
-		helpMessage += fmt.Sprintf(codeMessageFormat, star+space+authorizedUsersOnly) + newLine
+		helpMessage += fmt.Sprintf(codeMessageFormat, star+space+builtinText(botCtx, TemplateHelpAuthorizedOnly, nil)) + newLine
 	}
-	err := response.Reply(helpMessage)
+
+	helpMessage += s.reactionCommandsHelp(botCtx)
+	helpMessage += s.shortcutsHelp(botCtx)
+
+	err := response.Reply(helpMessage, WithEphemeral(s.ephemeralHelp))
 	if err != nil {
 		log.Println(err)
 	}
@@ -345,24 +462,67 @@ func (s *Slacker) prependHelpHandle() {
 	s.botCommands = append([]BotCommand{NewBotCommand(helpCommand, s.helpDefinition, true, defaultIncludeChannelIds)}, s.botCommands...)
 }
 
-func (s *Slacker) handleInteractiveEvent(slacker *Slacker, evt *socketmode.Event, callback *slack.InteractionCallback, req *socketmode.Request) {
+func (s *Slacker) handleInteractiveEvent(ctx context.Context, slacker *Slacker, evt *socketmode.Event, callback *slack.InteractionCallback, req *socketmode.Request) {
 	for _, cmd := range s.botCommands {
 		for _, action := range callback.ActionCallback.BlockActions {
 			if action.BlockID != cmd.Definition().BlockID {
 				continue
 			}
 
-			cmd.Interactive(slacker, evt, callback, req)
+			s.handleCommandInteraction(ctx, cmd, newInteraction(evt, callback, action), req)
 			return
 		}
 	}
 
+	if s.routeInteraction(ctx, evt, callback, req) {
+		return
+	}
+
 	if s.interactiveEventHandler != nil {
 		s.interactiveEventHandler(slacker, evt, callback)
 	}
 }
 
-func (s *Slacker) handleMessageEvent(ctx context.Context, evt interface{}, req *socketmode.Request) {
+// handleCommandInteraction runs the interactive handler of the command the
+// interaction's block belongs to, acknowledging the interaction once it returns
+func (s *Slacker) handleCommandInteraction(ctx context.Context, cmd BotCommand, interaction *Interaction, req *socketmode.Request) {
+	ack := newAcknowledger(s.socketModeClient, req)
+	defer ack.ack(nil)
+
+	ctx = withAcknowledger(withExecution(withSlacker(ctx, s), cmd), ack)
+	botCtx := s.botContextConstructor(ctx, s.client, s.socketModeClient, newInteractionMessageEvent(interaction.Callback))
+	response := s.responseConstructor(botCtx)
+	request := s.requestConstructor(botCtx, nil, &interactionMatch{interaction: interaction})
+
+	if cmd.Definition().AuthorizationFunc != nil && !cmd.Definition().AuthorizationFunc(botCtx, request) {
+		s.reportUnauthorized(botCtx, response)
+		return
+	}
+
+	executionFromContext(ctx).run(func() {
+		cmd.Interactive(botCtx, request, response)
+	})
+}
+
+// unauthorizedError returns the error reported to unauthorized users
+func (s *Slacker) unauthorizedError(botCtx BotContext) error {
+	if s.errUnauthorized != errUnauthorized {
+		return s.errUnauthorized
+	}
+	return errors.New(builtinText(botCtx, TemplateUnauthorized, nil))
+}
+
+// reportUnauthorized reports the unauthorized error, privately when
+// WithEphemeralUnauthorized is set and as configured for errors otherwise
+func (s *Slacker) reportUnauthorized(botCtx BotContext, response ResponseWriter) {
+	var options []ReportErrorOption
+	if s.ephemeralUnauthorized {
+		options = append(options, WithEphemeralError(true))
+	}
+	response.ReportError(s.unauthorizedError(botCtx), options...)
+}
+
+func (s *Slacker) ensureConstructors() {
 	if s.botContextConstructor == nil {
 		s.botContextConstructor = NewBotContext
 	}
@@ -374,6 +534,10 @@ func (s *Slacker) handleMessageEvent(ctx context.Context, evt interface{}, req *
 	if s.responseConstructor == nil {
 		s.responseConstructor = NewResponse
 	}
+}
+
+func (s *Slacker) handleMessageEvent(ctx context.Context, evt interface{}, req *socketmode.Request) {
+	s.ensureConstructors()
 
 	ev := newMessageEvent(s, evt, req)
 	if ev == nil {
@@ -404,8 +568,30 @@ func (s *Slacker) handleMessageEvent(ctx context.Context, evt interface{}, req *
 
 	}
 
-	botCtx := s.botContextConstructor(ctx, s.client, s.socketModeClient, ev)
+	switch messageSubType(ev) {
+	case messageDeletedSubType:
+		if s.editTracking {
+			s.deleteReplies(ev)
+		}
+		return
+	case messageChangedSubType:
+		if !s.editTracking || !isEdit(ev) {
+			return
+		}
+	}
+
+	if s.editTracking && req == nil && len(ev.TimeStamp) > 0 {
+		tracker := s.trackReplies(ev)
+		ctx = withReplyTracker(ctx, tracker)
+		defer s.saveReplies(ev, tracker)
+	}
+
+	botCtx := s.botContextConstructor(withSlacker(ctx, s), s.client, s.socketModeClient, ev)
 	response := s.responseConstructor(botCtx)
+	if s.continueConversation(botCtx, response) {
+		return
+	}
+
 	eventTxt := s.cleanEventInput(ev.Text)
 	var request Request
 	var parameters []allot.Parameter
@@ -429,9 +615,18 @@ func (s *Slacker) handleMessageEvent(ctx context.Context, evt interface{}, req *
 
 			}
 
+			if !s.channelPolicyAllows(cmd, ev) {
+				parameters, cmdMatch = nil, nil
+				continue
+			}
+
+			// handlers see the command they were matched for
+			botCtx = s.botContextConstructor(withExecution(withSlacker(ctx, s), cmd), s.client, s.socketModeClient, ev)
+			response = s.responseConstructor(botCtx)
+
 			request = s.requestConstructor(botCtx, parameters, cmdMatch)
 			if cmd.Definition().AuthorizationFunc != nil && !cmd.Definition().AuthorizationFunc(botCtx, request) {
-				response.ReportError(s.errUnauthorized)
+				s.reportUnauthorized(botCtx, response)
 				return
 			}
 
@@ -441,7 +636,8 @@ func (s *Slacker) handleMessageEvent(ctx context.Context, evt interface{}, req *
 				// full channel, dropped event
 			}
 
-			cmd.Execute(botCtx, request, response)
+			s.recordHistory(ev)
+			s.executeCommand(cmd, botCtx, request, response)
 			return
 		}
 	}
@@ -452,6 +648,20 @@ func (s *Slacker) handleMessageEvent(ctx context.Context, evt interface{}, req *
 	}
 }
 
+func (s *Slacker) channelPolicyAllows(cmd BotCommand, ev *MessageEvent) bool {
+	policy := cmd.Definition().ChannelPolicy
+	if policy == nil {
+		return true
+	}
+
+	allowed, err := policy.allows(s, ev)
+	if err != nil {
+		fmt.Printf("unable to evaluate channel policy of %q: %v\n", cmd.Usage(), err)
+		return false
+	}
+	return allowed
+}
+
 func getChannelName(slacker *Slacker, channelID string) string {
 	channel, err := slacker.client.GetConversationInfo(channelID, true)
 	if err != nil {
@@ -475,17 +685,26 @@ func newMessageEvent(slacker *Slacker, evt interface{}, req *socketmode.Request)
 
 	switch ev := evt.(type) {
 	case *slackevents.MessageEvent:
+		// edited and deleted messages are described by the nested message
+		source := ev
+		switch {
+		case ev.SubType == messageChangedSubType && ev.Message != nil:
+			source = ev.Message
+		case ev.SubType == messageDeletedSubType && ev.PreviousMessage != nil:
+			source = ev.PreviousMessage
+		}
+
 		me = &MessageEvent{
 			Channel:         ev.Channel,
 			ChannelName:     getChannelName(slacker, ev.Channel),
-			User:            ev.User,
-			UserName:        getUserName(slacker, ev.User),
-			Text:            ev.Text,
+			User:            source.User,
+			UserName:        getUserName(slacker, source.User),
+			Text:            source.Text,
 			Data:            evt,
 			Type:            ev.Type,
-			TimeStamp:       ev.TimeStamp,
-			ThreadTimeStamp: ev.ThreadTimeStamp,
-			BotID:           ev.BotID,
+			TimeStamp:       source.TimeStamp,
+			ThreadTimeStamp: source.ThreadTimeStamp,
+			BotID:           source.BotID,
 		}
 	case *slackevents.AppMentionEvent:
 		me = &MessageEvent{
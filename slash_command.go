@@ -0,0 +1,45 @@
+package slacker
+
+import (
+	"context"
+
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/socketmode"
+)
+
+// slashCommandPayload is a response to a slash command sent inside the
+// acknowledgement of the request
+type slashCommandPayload struct {
+	ResponseType string             `json:"response_type,omitempty"`
+	Text         string             `json:"text,omitempty"`
+	Attachments  []slack.Attachment `json:"attachments,omitempty"`
+	Blocks       []slack.Block      `json:"blocks,omitempty"`
+}
+
+// slashCommandResponder acknowledges a slash command exactly once, either with
+// the first reply of the handler or empty once the handler returns or the
+// acknowledgement deadline approaches
+type slashCommandResponder struct {
+	*acknowledger
+	command *slack.SlashCommand
+}
+
+func newSlashCommandResponder(client *socketmode.Client, command *slack.SlashCommand, request *socketmode.Request) *slashCommandResponder {
+	return &slashCommandResponder{acknowledger: newAcknowledger(client, request), command: command}
+}
+
+type slashCommandContextKey struct{}
+
+func withSlashCommandResponder(ctx context.Context, responder *slashCommandResponder) context.Context {
+	return context.WithValue(ctx, slashCommandContextKey{}, responder)
+}
+
+// slashCommandResponderFromContext returns the responder of the slash command
+// being handled, nil if the event is not a slash command
+func slashCommandResponderFromContext(ctx context.Context) *slashCommandResponder {
+	if ctx == nil {
+		return nil
+	}
+	responder, _ := ctx.Value(slashCommandContextKey{}).(*slashCommandResponder)
+	return responder
+}
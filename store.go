@@ -0,0 +1,285 @@
+package slacker
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	storeSweepInterval = time.Minute
+)
+
+// ErrStoreUnavailable is returned by the store of a context that was not
+// created by Slacker
+var ErrStoreUnavailable = errors.New("store is only available to handlers run by Slacker")
+
+// ErrStoreScopeUnavailable is returned by the store of a scope the event does
+// not have, eg. the thread of a slash command
+var ErrStoreScopeUnavailable = errors.New("store scope is not available for this event")
+
+// Store keeps state between handler invocations. A TTL of zero or less keeps
+// the value until it is deleted.
+type Store interface {
+	Get(key string) (value string, found bool, err error)
+	Set(key string, value string, ttl time.Duration) error
+	Delete(key string) error
+}
+
+// StoreScope determines which events share the values of a scoped store
+type StoreScope int
+
+const (
+	// StoreScopeUser shares values between all events of the same user
+	StoreScopeUser StoreScope = iota
+
+	// StoreScopeChannel shares values between all events of the same channel
+	StoreScopeChannel
+
+	// StoreScopeThread shares values between all events of the same thread
+	StoreScopeThread
+
+	// StoreScopeWorkspace shares values between all events
+	StoreScopeWorkspace
+)
+
+// scopePrefix returns the key prefix isolating the scope of the event. It
+// fails when the event lacks what the scope is keyed on, eg. the timestamp of
+// a slash command for StoreScopeThread.
+func scopePrefix(scope StoreScope, ev *MessageEvent) (string, error) {
+	if scope == StoreScopeWorkspace {
+		return "workspace/", nil
+	}
+	if ev == nil {
+		return empty, ErrStoreScopeUnavailable
+	}
+
+	switch scope {
+	case StoreScopeUser:
+		if len(ev.User) == 0 {
+			return empty, ErrStoreScopeUnavailable
+		}
+		return "user/" + ev.User + "/", nil
+	case StoreScopeChannel:
+		if len(ev.Channel) == 0 {
+			return empty, ErrStoreScopeUnavailable
+		}
+		return "channel/" + ev.Channel + "/", nil
+	case StoreScopeThread:
+		thread := ev.TimeStamp
+		if ev.IsThread() {
+			thread = ev.ThreadTimeStamp
+		}
+		if len(ev.Channel) == 0 || len(thread) == 0 {
+			return empty, ErrStoreScopeUnavailable
+		}
+		return "thread/" + ev.Channel + "/" + thread + "/", nil
+	default:
+		return empty, fmt.Errorf("unknown store scope %d", scope)
+	}
+}
+
+// StoreFor returns the bot's store, scoped to the user, channel, thread or
+// workspace of the event. Operations on the store fail with
+// ErrStoreUnavailable when the context was not created by Slacker, and with
+// ErrStoreScopeUnavailable when the event has no such scope.
+func StoreFor(botCtx BotContext, scope StoreScope) Store {
+	s := slackerFromContext(botCtx.Context())
+	if s == nil {
+		return unavailableStore{err: ErrStoreUnavailable}
+	}
+	return s.scopedStore(scope, botCtx.Event())
+}
+
+// scopedStore returns the store scoped to the event
+func (s *Slacker) scopedStore(scope StoreScope, ev *MessageEvent) Store {
+	prefix, err := scopePrefix(scope, ev)
+	if err != nil {
+		return unavailableStore{err: err}
+	}
+	return &scopedStore{store: s.store, prefix: prefix}
+}
+
+// scopedStore prefixes every key with the scope it was created for
+type scopedStore struct {
+	store  Store
+	prefix string
+}
+
+// Get returns the value of the key in the scope
+func (s *scopedStore) Get(key string) (string, bool, error) {
+	return s.store.Get(s.prefix + key)
+}
+
+// Set stores the value of the key in the scope
+func (s *scopedStore) Set(key string, value string, ttl time.Duration) error {
+	return s.store.Set(s.prefix+key, value, ttl)
+}
+
+// Delete removes the key from the scope
+func (s *scopedStore) Delete(key string) error {
+	return s.store.Delete(s.prefix + key)
+}
+
+// unavailableStore is handed out when the bot cannot be reached or the scope
+// cannot be resolved
+type unavailableStore struct {
+	err error
+}
+
+func (u unavailableStore) Get(string) (string, bool, error)        { return empty, false, u.err }
+func (u unavailableStore) Set(string, string, time.Duration) error { return u.err }
+func (u unavailableStore) Delete(string) error                     { return u.err }
+
+type storeEntry struct {
+	Value   string    `json:"value"`
+	Expires time.Time `json:"expires"`
+}
+
+func (e storeEntry) expired(now time.Time) bool {
+	return !e.Expires.IsZero() && now.After(e.Expires)
+}
+
+// NewMemoryStore creates a store that keeps values in memory
+func NewMemoryStore() Store {
+	return newMemoryStore()
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{entries: make(map[string]storeEntry)}
+}
+
+type memoryStore struct {
+	mutex     sync.Mutex
+	entries   map[string]storeEntry
+	lastSweep time.Time
+}
+
+// Get returns the value of the key if it did not expire
+func (m *memoryStore) Get(key string) (string, bool, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	entry, ok := m.entries[key]
+	if !ok {
+		return empty, false, nil
+	}
+	if entry.expired(time.Now()) {
+		delete(m.entries, key)
+		return empty, false, nil
+	}
+	return entry.Value, true, nil
+}
+
+// Set stores the value of the key
+func (m *memoryStore) Set(key string, value string, ttl time.Duration) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.set(key, value, ttl)
+	return nil
+}
+
+// Delete removes the key
+func (m *memoryStore) Delete(key string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	delete(m.entries, key)
+	return nil
+}
+
+// set stores the value, the caller must hold the mutex
+func (m *memoryStore) set(key string, value string, ttl time.Duration) {
+	now := time.Now()
+	entry := storeEntry{Value: value}
+	if ttl > 0 {
+		entry.Expires = now.Add(ttl)
+	}
+	m.entries[key] = entry
+
+	if now.Sub(m.lastSweep) < storeSweepInterval {
+		return
+	}
+	m.lastSweep = now
+	for key, entry := range m.entries {
+		if entry.expired(now) {
+			delete(m.entries, key)
+		}
+	}
+}
+
+// NewFileStore creates a store that keeps values in a JSON file, so that they
+// survive restarts. Existing values are loaded from the file if it exists.
+func NewFileStore(path string) (Store, error) {
+	store := &fileStore{memoryStore: newMemoryStore(), path: path}
+
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &store.entries); err != nil {
+			return nil, err
+		}
+	}
+	return store, nil
+}
+
+type fileStore struct {
+	*memoryStore
+	path string
+}
+
+// Set stores the value of the key and saves the file
+func (f *fileStore) Set(key string, value string, ttl time.Duration) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.set(key, value, ttl)
+	return f.save()
+}
+
+// Delete removes the key and saves the file
+func (f *fileStore) Delete(key string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if _, ok := f.entries[key]; !ok {
+		return nil
+	}
+	delete(f.entries, key)
+	return f.save()
+}
+
+// save atomically replaces the file, the caller must hold the mutex
+func (f *fileStore) save() error {
+	data, err := json.Marshal(f.entries)
+	if err != nil {
+		return err
+	}
+
+	file, err := ioutil.TempFile(filepath.Dir(f.path), filepath.Base(f.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), f.path)
+}
@@ -0,0 +1,296 @@
+package slacker
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+const (
+	// DefaultLocale is the locale of the templates used when none matches the
+	// user's Slack locale
+	DefaultLocale = ""
+
+	userLocaleTTL = time.Hour
+)
+
+// Names of the templates of the built-in messages, which can be overridden for
+// every locale
+const (
+	// TemplateError formats errors sent with ReportError. Data: .Error
+	TemplateError = "slacker.error"
+
+	// TemplateUnauthorized is the error reported when a user is not authorized
+	// to execute a command, unless set with UnAuthorizedError. No data.
+	TemplateUnauthorized = "slacker.unauthorized"
+
+	// TemplateHelpExample formats an example in the help message. Data: .Example
+	TemplateHelpExample = "slacker.help.example"
+
+	// TemplateHelpAuthorizedOnly explains the marker of commands requiring
+	// authorization in the help message. No data.
+	TemplateHelpAuthorizedOnly = "slacker.help.authorized_only"
+
+	// TemplateHelpReactions is the heading of the reaction commands in the help
+	// message. No data.
+	TemplateHelpReactions = "slacker.help.reactions"
+
+	// TemplateHelpShortcuts is the heading of the shortcuts in the help
+	// message. No data.
+	TemplateHelpShortcuts = "slacker.help.shortcuts"
+
+	// TemplateHelpMessageShortcut marks message shortcuts in the help message.
+	// No data.
+	TemplateHelpMessageShortcut = "slacker.help.message_shortcut"
+
+	// TemplateHomeCommands is the heading of the commands in the built-in
+	// home tab. No data.
+	TemplateHomeCommands = "slacker.home.commands"
+
+	// TemplateHomeHistory is the heading of the recent commands of the user in
+	// the built-in home tab. No data.
+	TemplateHomeHistory = "slacker.home.history"
+
+	// TemplateHomeNoHistory is shown in the built-in home tab to users who did
+	// not run any command yet. No data.
+	TemplateHomeNoHistory = "slacker.home.no_history"
+
+	// TemplateDMNotice points the user to a reply sent by direct message.
+	// Data: .User
+	TemplateDMNotice = "slacker.dm_notice"
+)
+
+var builtinTemplates = map[string]string{
+	TemplateError:               "*Error:* _{{.Error}}_",
+	TemplateUnauthorized:        "you are not authorized to execute this command",
+	TemplateHelpExample:         ">_*Example:* {{.Example}}_",
+	TemplateHelpAuthorizedOnly:  "Authorized users only",
+	TemplateHelpReactions:       "*Reactions*",
+	TemplateHelpShortcuts:       "*Shortcuts*",
+	TemplateHelpMessageShortcut: "on messages",
+	TemplateHomeCommands:        "Commands",
+	TemplateHomeHistory:         "Your recent commands",
+	TemplateHomeNoHistory:       "You have not run any commands yet",
+	TemplateDMNotice:            "<@{{.User}}> I sent you a direct message",
+}
+
+// defaultTemplates renders the built-in messages outside of a bot
+var defaultTemplates = NewTemplates()
+
+var templateFuncs = template.FuncMap{
+	// json quotes a value for use inside Block Kit JSON templates
+	"json": func(value interface{}) (string, error) {
+		data, err := json.Marshal(value)
+		return string(data), err
+	},
+}
+
+// Templates is a registry of message templates, written with text/template,
+// grouped by locale. Locales follow Slack's format, eg. "en-US", and lookups
+// fall back from "pt-BR" to "pt" and then to DefaultLocale.
+type Templates struct {
+	mutex   sync.RWMutex
+	locales map[string]map[string]*messageTemplate
+}
+
+type messageTemplate struct {
+	text   *template.Template
+	blocks *template.Template
+}
+
+// NewTemplates creates a registry holding the built-in templates
+func NewTemplates() *Templates {
+	t := &Templates{locales: make(map[string]map[string]*messageTemplate)}
+	for name, text := range builtinTemplates {
+		if err := t.Add(DefaultLocale, name, text); err != nil {
+			panic(err)
+		}
+	}
+	return t
+}
+
+// Add registers the text template of a message in the locale, replacing any
+// previous one
+func (t *Templates) Add(locale string, name string, text string) error {
+	parsed, err := template.New(name).Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return err
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.entry(locale, name).text = parsed
+	return nil
+}
+
+// AddBlocks registers a template producing the Block Kit JSON array of a
+// message in the locale. Use the json function to quote values, eg.
+// {"type": "plain_text", "text": {{json .Name}}}.
+func (t *Templates) AddBlocks(locale string, name string, text string) error {
+	parsed, err := template.New(name).Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return err
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.entry(locale, name).blocks = parsed
+	return nil
+}
+
+// Render renders the text template of the message in the locale
+func (t *Templates) Render(locale string, name string, data interface{}) (string, error) {
+	tmpl := t.lookup(locale, name, func(m *messageTemplate) *template.Template { return m.text })
+	if tmpl == nil {
+		return empty, fmt.Errorf("no text template named %q", name)
+	}
+	return execute(tmpl, data)
+}
+
+// RenderBlocks renders the Block Kit template of the message in the locale
+func (t *Templates) RenderBlocks(locale string, name string, data interface{}) ([]slack.Block, error) {
+	tmpl := t.lookup(locale, name, func(m *messageTemplate) *template.Template { return m.blocks })
+	if tmpl == nil {
+		return nil, fmt.Errorf("no blocks template named %q", name)
+	}
+
+	text, err := execute(tmpl, data)
+	if err != nil {
+		return nil, err
+	}
+
+	var blocks slack.Blocks
+	if err := json.Unmarshal([]byte(text), &blocks); err != nil {
+		return nil, fmt.Errorf("invalid blocks rendered by %q: %v", name, err)
+	}
+	return blocks.BlockSet, nil
+}
+
+// hasLocales indicates if templates were registered for other locales than
+// the default, in which case the locale of users is looked up
+func (t *Templates) hasLocales() bool {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	for locale := range t.locales {
+		if locale != DefaultLocale {
+			return true
+		}
+	}
+	return false
+}
+
+// entry returns the templates of the message, the caller must hold the mutex
+func (t *Templates) entry(locale string, name string) *messageTemplate {
+	if t.locales == nil {
+		t.locales = make(map[string]map[string]*messageTemplate)
+	}
+
+	templates, ok := t.locales[locale]
+	if !ok {
+		templates = make(map[string]*messageTemplate)
+		t.locales[locale] = templates
+	}
+
+	entry, ok := templates[name]
+	if !ok {
+		entry = &messageTemplate{}
+		templates[name] = entry
+	}
+	return entry
+}
+
+// lookup returns the template of the message in the closest locale
+func (t *Templates) lookup(locale string, name string, kind func(*messageTemplate) *template.Template) *template.Template {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	for _, candidate := range localeFallbacks(locale) {
+		if entry, ok := t.locales[candidate][name]; ok && kind(entry) != nil {
+			return kind(entry)
+		}
+	}
+	return nil
+}
+
+// localeFallbacks returns the locales to try for the locale, most specific
+// first
+func localeFallbacks(locale string) []string {
+	fallbacks := []string{locale}
+	if index := strings.IndexAny(locale, "-_"); index > 0 {
+		fallbacks = append(fallbacks, locale[:index])
+	}
+	return append(fallbacks, DefaultLocale)
+}
+
+func execute(tmpl *template.Template, data interface{}) (string, error) {
+	var buffer bytes.Buffer
+	if err := tmpl.Execute(&buffer, data); err != nil {
+		return empty, err
+	}
+	return buffer.String(), nil
+}
+
+type userLocaleCache struct {
+	mutex   sync.Mutex
+	locales map[string]cachedUserLocale
+}
+
+type cachedUserLocale struct {
+	locale  string
+	expires time.Time
+}
+
+// userLocale returns the Slack locale of the user, DefaultLocale when the
+// templates do not depend on it or it cannot be retrieved
+func (s *Slacker) userLocale(userID string) string {
+	if len(userID) == 0 || !s.Templates().hasLocales() {
+		return DefaultLocale
+	}
+
+	cache := &s.localeCache
+	cache.mutex.Lock()
+	cached, ok := cache.locales[userID]
+	cache.mutex.Unlock()
+	if ok && time.Now().Before(cached.expires) {
+		return cached.locale
+	}
+
+	user, err := s.client.GetUserInfo(userID)
+	if err != nil {
+		fmt.Printf("unable to get locale of %s: %v\n", userID, err)
+		return DefaultLocale
+	}
+
+	cache.mutex.Lock()
+	if cache.locales == nil {
+		cache.locales = make(map[string]cachedUserLocale)
+	}
+	cache.locales[userID] = cachedUserLocale{locale: user.Locale, expires: time.Now().Add(userLocaleTTL)}
+	cache.mutex.Unlock()
+	return user.Locale
+}
+
+// builtinText renders a built-in message in the locale of the user who
+// triggered the event
+func builtinText(botCtx BotContext, name string, data interface{}) string {
+	templates, locale := defaultTemplates, DefaultLocale
+	if s := slackerFromContext(botCtx.Context()); s != nil {
+		templates = s.Templates()
+		if ev := botCtx.Event(); ev != nil {
+			locale = s.userLocale(ev.User)
+		}
+	}
+
+	text, err := templates.Render(locale, name, data)
+	if err != nil {
+		fmt.Printf("unable to render %q: %v\n", name, err)
+		text, _ = defaultTemplates.Render(DefaultLocale, name, data)
+	}
+	return text
+}
@@ -0,0 +1,31 @@
+package slacker
+
+import (
+	"context"
+	"testing"
+)
+
+func TestBuiltinTextWithDefaultClient(t *testing.T) {
+	s := NewClient("xoxb-token", "xapp-token")
+	ev := &MessageEvent{Channel: "C1", User: "U1"}
+	botCtx := NewBotContext(withSlacker(context.Background(), s), s.client, s.socketModeClient, ev)
+
+	text := builtinText(botCtx, TemplateUnauthorized, nil)
+	if text != builtinTemplates[TemplateUnauthorized] {
+		t.Errorf("expected %q, got %q", builtinTemplates[TemplateUnauthorized], text)
+	}
+
+	if s.Templates() == nil {
+		t.Error("expected the client to have templates")
+	}
+}
+
+func TestBuiltinTextWithoutTemplates(t *testing.T) {
+	s := &Slacker{}
+	botCtx := NewBotContext(withSlacker(context.Background(), s), nil, nil, &MessageEvent{User: "U1"})
+
+	text := builtinText(botCtx, TemplateError, map[string]string{"Error": "boom"})
+	if text != "*Error:* _boom_" {
+		t.Errorf("unexpected text %q", text)
+	}
+}
@@ -0,0 +1,197 @@
+package slacker
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/slackevents"
+)
+
+const (
+	linkSharedType   = "link_shared"
+	maxUnfurlDomains = 5
+)
+
+// domainExpression recognizes plain domains, which are matched with their
+// subdomains rather than as patterns
+var domainExpression = regexp.MustCompile(`^[a-zA-Z0-9-]+(\.[a-zA-Z0-9-]+)+$`)
+
+// Link is a shared link matched by an unfurl handler
+type Link struct {
+	URL    string
+	Domain string
+
+	// Match holds the submatches of the pattern the link matched, eg. the
+	// ticket ID captured by "https://tickets\.example\.com/(\d+)". Empty for
+	// handlers registered for a domain.
+	Match []string
+}
+
+// Unfurl is the preview of a link, made of blocks or a legacy attachment
+type Unfurl struct {
+	Blocks     []slack.Block
+	Attachment *slack.Attachment
+}
+
+// UnfurlHandler returns the previews of the links, by URL. Links left out of
+// the result are not unfurled.
+type UnfurlHandler func(botCtx BotContext, links []*Link) (map[string]*Unfurl, error)
+
+type unfurl struct {
+	domainOrPattern string
+	domain          string
+	pattern         *regexp.Regexp
+	handler         UnfurlHandler
+	err             error
+}
+
+// Unfurl registers a handler previewing links shared in messages. A plain
+// domain, eg. "tickets.example.com", matches links to the domain and its
+// subdomains. Anything else is a regular expression the whole URL must match,
+// eg. "https://grafana\.example\.com/d/(\w+)/.*". The domains also need to be
+// listed in the app's unfurl domains, see Manifest.
+func (s *Slacker) Unfurl(domainOrPattern string, handler UnfurlHandler) {
+	u := &unfurl{domainOrPattern: domainOrPattern, handler: handler}
+	if domainExpression.MatchString(domainOrPattern) {
+		u.domain = strings.ToLower(domainOrPattern)
+	} else {
+		u.pattern, u.err = regexp.Compile("^(?:" + domainOrPattern + ")$")
+		if u.err == nil {
+			u.domain = patternDomain(u.pattern)
+		}
+	}
+	s.unfurls = append(s.unfurls, u)
+}
+
+// patternDomain returns the domain a pattern matches, taken from the literal
+// start of the expression, empty if it cannot be determined
+func patternDomain(pattern *regexp.Regexp) string {
+	prefix, _ := pattern.LiteralPrefix()
+	if !strings.Contains(prefix, "://") {
+		return empty
+	}
+
+	parsed, err := url.Parse(prefix)
+	if err != nil || len(parsed.Path) == 0 {
+		return empty
+	}
+	return strings.ToLower(parsed.Hostname())
+}
+
+// match returns the link if the handler unfurls it
+func (u *unfurl) match(rawURL string, domain string) *Link {
+	if u.err != nil || u.handler == nil {
+		return nil
+	}
+
+	if u.pattern != nil {
+		match := u.pattern.FindStringSubmatch(rawURL)
+		if match == nil {
+			return nil
+		}
+		return &Link{URL: rawURL, Domain: domain, Match: match}
+	}
+
+	domain = strings.ToLower(domain)
+	if domain != u.domain && !strings.HasSuffix(domain, "."+u.domain) {
+		return nil
+	}
+	return &Link{URL: rawURL, Domain: domain}
+}
+
+// validate records the problems of the handler
+func (u *unfurl) validate(result *ValidationResult) {
+	if u.err != nil {
+		result.errorf("unfurl pattern %q is invalid: %v", u.domainOrPattern, u.err)
+		return
+	}
+	if u.handler == nil {
+		result.errorf("unfurl %q has no handler", u.domainOrPattern)
+	}
+	if len(u.domain) == 0 {
+		result.warnf("unfurl pattern %q does not start with a URL, its domain is left out of the manifest", u.domainOrPattern)
+	}
+}
+
+// unfurlDomains lists the domains of the unfurl handlers
+func (s *Slacker) unfurlDomains() []string {
+	domains := newStringSet()
+	for _, u := range s.unfurls {
+		if len(u.domain) > 0 {
+			domains.add(u.domain)
+		}
+	}
+	return domains.sorted()
+}
+
+func (s *Slacker) handleLinkShared(ctx context.Context, event *slackevents.LinkSharedEvent) {
+	// links shared in the message composer have no message to unfurl
+	if _, err := strconv.ParseFloat(event.MessageTimeStamp, 64); err != nil {
+		return
+	}
+
+	// each link is unfurled by the first handler matching it
+	links := make(map[*unfurl][]*Link)
+	for _, shared := range event.Links {
+		for _, u := range s.unfurls {
+			if link := u.match(shared.URL, shared.Domain); link != nil {
+				links[u] = append(links[u], link)
+				break
+			}
+		}
+	}
+	if len(links) == 0 {
+		return
+	}
+
+	ev := newEventsAPIMessageEvent(linkSharedType, event)
+	botCtx := s.botContextConstructor(withSlacker(ctx, s), s.client, s.socketModeClient, ev)
+
+	unfurls := make(map[string]slack.Attachment)
+	for _, u := range s.unfurls {
+		if len(links[u]) == 0 {
+			continue
+		}
+
+		previews, err := s.runUnfurlHandler(u, botCtx, links[u])
+		if err != nil {
+			s.reportError(fmt.Errorf("unable to unfurl links of %q: %v", u.domainOrPattern, err))
+			continue
+		}
+
+		for rawURL, preview := range previews {
+			if preview == nil {
+				continue
+			}
+			attachment := slack.Attachment{}
+			if preview.Attachment != nil {
+				attachment = *preview.Attachment
+			}
+			if len(preview.Blocks) > 0 {
+				attachment.Blocks = slack.Blocks{BlockSet: preview.Blocks}
+			}
+			unfurls[rawURL] = attachment
+		}
+	}
+	if len(unfurls) == 0 {
+		return
+	}
+
+	if _, _, _, err := s.client.UnfurlMessageContext(ctx, event.Channel, event.MessageTimeStamp, unfurls); err != nil {
+		s.reportError(fmt.Errorf("unable to unfurl links in %s: %v", event.MessageTimeStamp, err))
+	}
+}
+
+func (s *Slacker) runUnfurlHandler(u *unfurl, botCtx BotContext, links []*Link) (previews map[string]*Unfurl, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("handler panicked: %v", r)
+		}
+	}()
+	return u.handler(botCtx, links)
+}
@@ -0,0 +1,79 @@
+package slacker
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/slack-go/slack"
+)
+
+const (
+	defaultUploadFilename = "file"
+)
+
+// UploadSource is the content of a file to upload
+type UploadSource struct {
+	reader io.Reader
+	path   string
+}
+
+// UploadBytes uploads the given content
+func UploadBytes(content []byte) UploadSource {
+	return UploadSource{reader: bytes.NewReader(content)}
+}
+
+// UploadReader uploads the content read from the reader
+func UploadReader(reader io.Reader) UploadSource {
+	return UploadSource{reader: reader}
+}
+
+// UploadPath uploads the file at the given path, named after it unless a
+// filename is set
+func UploadPath(path string) UploadSource {
+	return UploadSource{path: path}
+}
+
+// Upload uploads a file to the channel the event was received from
+func Upload(response ResponseWriter, source UploadSource, options ...UploadOption) (*slack.File, error) {
+	writer, ok := response.(interface {
+		Upload(source UploadSource, options ...UploadOption) (*slack.File, error)
+	})
+	if !ok {
+		return nil, ErrUnsupportedResponseWriter
+	}
+	return writer.Upload(source, options...)
+}
+
+// Upload uploads a file to the channel the event was received from
+func (r *response) Upload(source UploadSource, options ...UploadOption) (*slack.File, error) {
+	defaults := NewUploadDefaults(options...)
+
+	ev := r.botCtx.Event()
+	if ev == nil {
+		return nil, fmt.Errorf("unable to get message event details")
+	}
+
+	params := slack.FileUploadParameters{
+		File:            source.path,
+		Reader:          source.reader,
+		Filename:        defaults.Filename,
+		Filetype:        defaults.Filetype,
+		Title:           defaults.Title,
+		InitialComment:  defaults.InitialComment,
+		Channels:        []string{ev.Channel},
+		ThreadTimestamp: replyThread(r.botCtx.Context(), ev, defaults.ThreadResponse),
+	}
+
+	switch {
+	case len(params.File) > 0 && len(params.Filename) == 0:
+		params.Filename = filepath.Base(params.File)
+	case params.Reader != nil && len(params.Filename) == 0:
+		params.Filename = defaultUploadFilename
+	case len(params.File) == 0 && params.Reader == nil:
+		return nil, fmt.Errorf("nothing to upload")
+	}
+
+	return r.botCtx.Client().UploadFileContext(r.botCtx.Context(), params)
+}
@@ -0,0 +1,217 @@
+package slacker
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ValidationMode instructs Slacker on how to handle problems found in the
+// command table before connecting.
+type ValidationMode int
+
+const (
+	// ValidationModeWarn prints both errors and warnings but lets Listen
+	// connect anyway. This is the default.
+	ValidationModeWarn ValidationMode = iota
+
+	// ValidationModeStrict prints warnings and makes Listen return an error
+	// when the command table contains mistakes.
+	ValidationModeStrict
+
+	// ValidationModeOff skips the validation pass entirely.
+	ValidationModeOff
+)
+
+var bracedParameterRegex = regexp.MustCompile(`\{([^{}\s]+)\}`)
+
+// ValidationResult contains the problems found in the registered commands.
+// Errors describe commands that cannot work as defined, while Warnings
+// describe definitions that are likely unintended.
+type ValidationResult struct {
+	Errors   []string
+	Warnings []string
+}
+
+// Err returns an error describing all validation errors, nil if there are none
+func (v *ValidationResult) Err() error {
+	if len(v.Errors) == 0 {
+		return nil
+	}
+	return errors.New("invalid command definitions:\n\t" + strings.Join(v.Errors, "\n\t"))
+}
+
+func (v *ValidationResult) errorf(format string, args ...interface{}) {
+	v.Errors = append(v.Errors, fmt.Sprintf(format, args...))
+}
+
+func (v *ValidationResult) warnf(format string, args ...interface{}) {
+	v.Warnings = append(v.Warnings, fmt.Sprintf(format, args...))
+}
+
+// Validate checks the registered commands for duplicate usages, patterns that
+// can never match, examples that do not match their own usage, invalid channel
+// policies, block IDs shared between commands and commands shadowed by earlier
+// registrations.
+func (s *Slacker) Validate() *ValidationResult {
+	result := &ValidationResult{}
+
+	usages := make(map[string]int)
+	blockIDs := make(map[string]string)
+	for _, cmd := range s.botCommands {
+		usage := cmd.Usage()
+		usages[usage]++
+		if usages[usage] == 2 {
+			result.errorf("usage %q is registered more than once", usage)
+		}
+
+		validateUsage(result, cmd)
+
+		if policy := cmd.Definition().ChannelPolicy; policy != nil {
+			if err := policy.validate(); err != nil {
+				result.errorf("channel policy of %q: %v", usage, err)
+			}
+		}
+
+		blockID := cmd.Definition().BlockID
+		if len(blockID) == 0 {
+			continue
+		}
+		if other, ok := blockIDs[blockID]; ok {
+			result.errorf("block ID %q is shared by %q and %q, interactions will only reach %q", blockID, other, usage, other)
+			continue
+		}
+		blockIDs[blockID] = usage
+	}
+
+	emojis := make(map[string]int)
+	for _, cmd := range s.reactionCommands {
+		usage := cmd.Usage()
+		emojis[usage]++
+		if emojis[usage] == 2 {
+			result.warnf("reaction %s is registered more than once, only the first command matching the channel runs", usage)
+		}
+		if usage == emojiUsage(empty) {
+			result.errorf("reaction command has no emoji")
+		}
+		if cmd.Definition().Handler == nil {
+			result.errorf("reaction command %s has no handler", usage)
+		}
+		if policy := cmd.Definition().ChannelPolicy; policy != nil {
+			if err := policy.validate(); err != nil {
+				result.errorf("channel policy of %s: %v", usage, err)
+			}
+		}
+	}
+
+	for _, route := range s.interactions {
+		route.validate(result)
+	}
+
+	for _, u := range s.unfurls {
+		u.validate(result)
+	}
+	if domains := s.unfurlDomains(); len(domains) > maxUnfurlDomains {
+		result.warnf("unfurls cover %d domains, Slack apps can list at most %d", len(domains), maxUnfurlDomains)
+	}
+
+	callbackIDs := make(map[string]bool)
+	for _, shortcut := range s.shortcuts {
+		if callbackIDs[shortcut.callbackID] {
+			result.errorf("shortcut callback ID %q is registered more than once", shortcut.callbackID)
+		}
+		callbackIDs[shortcut.callbackID] = true
+
+		if len(shortcut.callbackID) == 0 {
+			result.errorf("shortcut %q has no callback ID", shortcut.definition.Name)
+		}
+		if len(shortcut.definition.Name) == 0 {
+			result.errorf("shortcut %q has no name", shortcut.callbackID)
+		}
+		if shortcut.definition.Handler == nil {
+			result.errorf("shortcut %q has no handler", shortcut.callbackID)
+		}
+	}
+
+	for i, cmd := range s.botCommands {
+		for _, example := range cmd.Definition().Examples {
+			// examples of bot commands may leave out the bot prefix
+			if strings.HasPrefix(cmd.Usage(), botPrefix) && !strings.HasPrefix(strings.ToLower(example), "bot ") {
+				example = "bot " + example
+			}
+
+			matched, err := commandMatches(cmd, example)
+			if err != nil {
+				// already reported by validateUsage
+				break
+			}
+			if !matched {
+				result.errorf("example %q does not match usage %q", example, cmd.Usage())
+				continue
+			}
+
+			for _, earlier := range s.botCommands[:i] {
+				if earlier.Usage() == cmd.Usage() {
+					continue
+				}
+				if matched, _ := commandMatches(earlier, example); matched {
+					result.warnf("example %q of %q is handled by %q which is registered earlier", example, cmd.Usage(), earlier.Usage())
+					break
+				}
+			}
+		}
+	}
+
+	return result
+}
+
+func validateUsage(result *ValidationResult, cmd BotCommand) {
+	usage := cmd.Usage()
+	if len(strings.TrimSpace(usage)) == 0 {
+		if cmd.IsParameterizedCommand() {
+			result.errorf("command with empty usage can never match")
+		} else {
+			result.warnf("command with empty usage matches every message")
+		}
+		return
+	}
+
+	if !cmd.IsParameterizedCommand() {
+		if usage != strings.ToLower(usage) {
+			result.errorf("usage %q contains upper case letters and can never match a non-parameterized command", usage)
+		}
+		return
+	}
+
+	unknownType := false
+	for _, parameter := range cmd.Parameters() {
+		if parameter.Expression() == nil {
+			unknownType = true
+			result.errorf("usage %q has parameter %q of unknown type %q", usage, parameter.Name(), parameter.Datatype())
+		}
+	}
+
+	if _, err := commandMatches(cmd, usage); err != nil && !unknownType {
+		result.errorf("usage %q can never match: %v", usage, err)
+	}
+
+	for _, braced := range bracedParameterRegex.FindAllStringSubmatch(usage, -1) {
+		result.warnf("usage %q matches %q literally, did you mean <%s>?", usage, braced[0], braced[1])
+	}
+}
+
+// commandMatches reports whether text triggers cmd. Malformed usages make the
+// underlying expression panic, which is turned into an error instead.
+func commandMatches(cmd BotCommand, text string) (matched bool, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	if cmd.IsParameterizedCommand() {
+		return cmd.Matches(text), nil
+	}
+	return cmd.MsgContains(text), nil
+}